@@ -0,0 +1,149 @@
+// Package domainerr はユースケースが返すエラーの種類を表すパッケージです
+// ハンドラーはエラーメッセージではなく種類によってHTTPステータスコードを決定します
+// メッセージはメッセージIDで保持し、レスポンスを返す際にリクエストの言語に翻訳します
+package domainerr
+
+import (
+	"errors"
+
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// Kind はエラーの種類です
+type Kind int
+
+const (
+	// KindInternal は種類が特定されていない内部エラーです
+	KindInternal Kind = iota
+	// KindNotFound は対象が存在しないことを表します
+	KindNotFound
+	// KindForbidden は対象を操作する権限がないことを表します
+	KindForbidden
+	// KindConflict は対象の現在の状態と矛盾する操作であることを表します
+	KindConflict
+	// KindValidation は入力値が不正であることを表します
+	KindValidation
+	// KindUnauthorized は認証に失敗したことを表します
+	KindUnauthorized
+)
+
+// FieldError は入力値の項目ごとのエラーです
+type FieldError struct {
+	Field   string
+	Message i18n.MessageID
+	Args    []any
+}
+
+// Localize はメッセージを指定された言語に翻訳します
+func (f FieldError) Localize(lang i18n.Language) string {
+	return i18n.Translate(lang, f.Message, f.Args...)
+}
+
+// Error はユースケースが返す種類付きのエラーです
+// Argsはメッセージの書式に埋め込む値です
+type Error struct {
+	Kind    Kind
+	Message i18n.MessageID
+	Args    []any
+	Fields  []FieldError
+	Err     error
+}
+
+var (
+	// ErrNotFound はerrors.Isで対象が存在しないエラーかどうかを判定するための値です
+	ErrNotFound = &Error{Kind: KindNotFound}
+	// ErrForbidden はerrors.Isで権限がないエラーかどうかを判定するための値です
+	ErrForbidden = &Error{Kind: KindForbidden}
+	// ErrConflict はerrors.Isで状態と矛盾するエラーかどうかを判定するための値です
+	ErrConflict = &Error{Kind: KindConflict}
+	// ErrValidation はerrors.Isで入力値が不正なエラーかどうかを判定するための値です
+	ErrValidation = &Error{Kind: KindValidation}
+	// ErrUnauthorized はerrors.Isで認証に失敗したエラーかどうかを判定するための値です
+	ErrUnauthorized = &Error{Kind: KindUnauthorized}
+)
+
+// NotFound は対象が存在しないことを表すエラーを作成します
+func NotFound(message i18n.MessageID, args ...any) *Error {
+	return &Error{Kind: KindNotFound, Message: message, Args: args}
+}
+
+// Forbidden は対象を操作する権限がないことを表すエラーを作成します
+func Forbidden(message i18n.MessageID, args ...any) *Error {
+	return &Error{Kind: KindForbidden, Message: message, Args: args}
+}
+
+// Conflict は対象の現在の状態と矛盾する操作であることを表すエラーを作成します
+func Conflict(message i18n.MessageID, args ...any) *Error {
+	return &Error{Kind: KindConflict, Message: message, Args: args}
+}
+
+// Validation は入力値が不正であることを表すエラーを作成します
+// fieldsには不正な項目ごとの詳細を指定できます
+func Validation(message i18n.MessageID, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+// InvalidField は1つの項目が不正であることを表すエラーを作成します
+func InvalidField(field string, message i18n.MessageID, args ...any) *Error {
+	err := Validation(message, FieldError{Field: field, Message: message, Args: args})
+	err.Args = args
+
+	return err
+}
+
+// Unauthorized は認証に失敗したことを表すエラーを作成します
+func Unauthorized(message i18n.MessageID, args ...any) *Error {
+	return &Error{Kind: KindUnauthorized, Message: message, Args: args}
+}
+
+// Wrap は原因となったエラーを保持したまま、メッセージを置き換えたエラーを作成します
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+
+	return &wrapped
+}
+
+// Localize はエラーメッセージを指定された言語に翻訳します
+// 原因となったエラーの内容は翻訳されておらず内部の情報を含むことがあるため、クライアントに返すこのメッセージには含めません
+func (e *Error) Localize(lang i18n.Language) string {
+	return i18n.Translate(lang, e.Message, e.Args...)
+}
+
+// Error は既定の言語のエラーメッセージを返します。ログに出力できるよう、原因となったエラーがある場合はその内容を続けます
+func (e *Error) Error() string {
+	message := e.Localize(i18n.Default)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+
+	return message
+}
+
+// Unwrap は原因となったエラーを返します
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is はtargetがErrNotFoundなどの種類を表す値の場合、種類が一致するかどうかを返します
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Message == "" && t.Fields == nil && t.Err == nil {
+		return t.Kind == e.Kind
+	}
+
+	return t == e
+}
+
+// KindOf はエラーの種類を返します。種類付きのエラーでない場合はKindInternalを返します
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+
+	return KindInternal
+}
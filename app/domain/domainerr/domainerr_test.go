@@ -0,0 +1,24 @@
+package domainerr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+func TestLocalizeOmitsCause(t *testing.T) {
+	cause := errors.New("token is malformed: could not base64 decode header")
+	err := Unauthorized(i18n.TokenInvalid).Wrap(cause)
+
+	if got, want := err.Localize(i18n.English), "Invalid token"; got != want {
+		t.Errorf("Localize() = %q, want %q", got, want)
+	}
+	if got := err.Error(); !strings.Contains(got, cause.Error()) {
+		t.Errorf("Error() = %q, want it to contain the cause %q", got, cause.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false, want true")
+	}
+}
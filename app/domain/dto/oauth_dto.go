@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// OAuthClientResponse はOAuthクライアントの情報を表す構造体です
+// クライアントシークレットは含めません
+type OAuthClientResponse struct {
+	ID           uint          `json:"id"`
+	ClientID     string        `json:"client_id"`
+	Name         string        `json:"name"`
+	RedirectURIs []string      `json:"redirect_uris"`
+	Scopes       []model.Scope `json:"scopes"`
+	Confidential bool          `json:"confidential"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// RegisteredOAuthClientResponse は登録したOAuthクライアントを表す構造体です
+// クライアントシークレットは機密クライアントの登録時のこのレスポンスでのみ返します
+type RegisteredOAuthClientResponse struct {
+	*OAuthClientResponse
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuthConsentResponse はユーザーに同意を求める認可リクエストの内容を表す構造体です
+type OAuthConsentResponse struct {
+	ClientID    string        `json:"client_id"`
+	ClientName  string        `json:"client_name"`
+	RedirectURI string        `json:"redirect_uri"`
+	Scopes      []model.Scope `json:"scopes"`
+	State       string        `json:"state,omitempty"`
+}
+
+// OAuthClientモデルから必要なフィールドだけを取り出すマッパー関数
+func ToOAuthClientResponse(client *model.OAuthClient) *OAuthClientResponse {
+	return &OAuthClientResponse{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIList(),
+		Scopes:       client.ScopeList(),
+		Confidential: client.IsConfidential(),
+		CreatedAt:    client.CreatedAt,
+	}
+}
+
+// スライス変換用のヘルパー関数
+func ToOAuthClientResponseList(clients []*model.OAuthClient) []*OAuthClientResponse {
+	result := make([]*OAuthClientResponse, len(clients))
+	for i, client := range clients {
+		result[i] = ToOAuthClientResponse(client)
+	}
+	return result
+}
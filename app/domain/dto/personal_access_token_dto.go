@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// PersonalAccessTokenResponse は個人用アクセストークンの情報を表す構造体です
+// トークン自体は含めず、見分けるための先頭部分のみを返します
+type PersonalAccessTokenResponse struct {
+	ID          uint          `json:"id"`
+	Name        string        `json:"name"`
+	TokenPrefix string        `json:"token_prefix"`
+	Scopes      []model.Scope `json:"scopes"`
+	ExpiresAt   *time.Time    `json:"expires_at"`
+	LastUsedAt  *time.Time    `json:"last_used_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// CreatedPersonalAccessTokenResponse は作成した個人用アクセストークンを表す構造体です
+// トークン自体は作成時のこのレスポンスでのみ返します
+type CreatedPersonalAccessTokenResponse struct {
+	*PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
+
+// PersonalAccessTokenモデルから必要なフィールドだけを取り出すマッパー関数
+func ToPersonalAccessTokenResponse(token *model.PersonalAccessToken) *PersonalAccessTokenResponse {
+	return &PersonalAccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+// スライス変換用のヘルパー関数
+func ToPersonalAccessTokenResponseList(tokens []*model.PersonalAccessToken) []*PersonalAccessTokenResponse {
+	result := make([]*PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		result[i] = ToPersonalAccessTokenResponse(token)
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// ProblemContentType はエラーレスポンスのContent-Typeです
+const ProblemContentType = "application/problem+json"
+
+// ProblemResponse はRFC 7807形式のエラーレスポンスを表す構造体です
+// Errorsには入力値に誤りがある場合の項目ごとの詳細が入ります
+type ProblemResponse struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Errors   []*ProblemFieldError `json:"errors,omitempty"`
+}
+
+// ProblemFieldError は入力値の項目ごとのエラーを表す構造体です
+type ProblemFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// 項目ごとのエラーを指定された言語のメッセージに変換するマッパー関数
+func ToProblemFieldErrorList(fields []domainerr.FieldError, lang i18n.Language) []*ProblemFieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]*ProblemFieldError, len(fields))
+	for i, field := range fields {
+		result[i] = &ProblemFieldError{
+			Field:   field.Field,
+			Message: field.Localize(lang),
+		}
+	}
+	return result
+}
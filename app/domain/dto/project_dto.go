@@ -0,0 +1,32 @@
+package dto
+
+import "github.com/jugeeem/golang-todo.git/app/domain/model"
+
+// ProjectResponse はプロジェクト情報を表す構造体です
+type ProjectResponse struct {
+	ID                 uint   `json:"id"`
+	Name               string `json:"name"`
+	Archived           bool   `json:"archived"`
+	OpenTodoCount      int64  `json:"open_todo_count"`
+	CompletedTodoCount int64  `json:"completed_todo_count"`
+}
+
+// Projectモデルから必要なフィールドだけを取り出すマッパー関数
+func ToProjectResponse(project *model.Project) *ProjectResponse {
+	return &ProjectResponse{
+		ID:                 project.ID,
+		Name:               project.Name,
+		Archived:           project.Archived,
+		OpenTodoCount:      project.OpenTodoCount,
+		CompletedTodoCount: project.CompletedTodoCount,
+	}
+}
+
+// スライス変換用のヘルパー関数
+func ToProjectResponseList(projects []*model.Project) []*ProjectResponse {
+	result := make([]*ProjectResponse, len(projects))
+	for i, project := range projects {
+		result[i] = ToProjectResponse(project)
+	}
+	return result
+}
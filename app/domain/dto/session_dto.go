@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// SessionResponse はセッション情報を表す構造体です
+// Currentはリクエストに使われたトークンのセッションかどうかを表します
+// OAuthクライアントに認可したセッションの場合は、OAuthClientIDと認可したScopesを含みます
+type SessionResponse struct {
+	ID            uint          `json:"id"`
+	UserAgent     string        `json:"user_agent"`
+	IPAddress     string        `json:"ip_address"`
+	OAuthClientID *uint         `json:"oauth_client_id,omitempty"`
+	Scopes        []model.Scope `json:"scopes,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	LastSeenAt    time.Time     `json:"last_seen_at"`
+	Current       bool          `json:"current"`
+}
+
+// Sessionモデルから必要なフィールドだけを取り出すマッパー関数
+func ToSessionResponse(session *model.Session, currentSessionID uint) *SessionResponse {
+	return &SessionResponse{
+		ID:            session.ID,
+		UserAgent:     session.UserAgent,
+		IPAddress:     session.IPAddress,
+		OAuthClientID: session.OAuthClientID,
+		Scopes:        session.ScopeList(),
+		CreatedAt:     session.CreatedAt,
+		LastSeenAt:    session.LastSeenAt,
+		Current:       session.ID == currentSessionID,
+	}
+}
+
+// スライス変換用のヘルパー関数
+func ToSessionResponseList(sessions []*model.Session, currentSessionID uint) []*SessionResponse {
+	result := make([]*SessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = ToSessionResponse(session, currentSessionID)
+	}
+	return result
+}
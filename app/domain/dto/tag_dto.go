@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/jugeeem/golang-todo.git/app/domain/model"
+
+// TagResponse はタグ情報を表す構造体です
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Tagモデルから必要なフィールドだけを取り出すマッパー関数
+func ToTagResponse(tag *model.Tag) *TagResponse {
+	return &TagResponse{
+		ID:   tag.ID,
+		Name: tag.Name,
+	}
+}
+
+// スライス変換用のヘルパー関数
+func ToTagResponseList(tags []*model.Tag) []*TagResponse {
+	result := make([]*TagResponse, len(tags))
+	for i, tag := range tags {
+		result[i] = ToTagResponse(tag)
+	}
+	return result
+}
@@ -1,24 +1,54 @@
 package dto
 
-import "github.com/jugeeem/golang-todo.git/app/domain/model"
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
 
 type TodoResponse struct {
-	ID          uint   `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Completed   bool   `json:"completed"`
-	UserID      uint   `json:"user_id"`
+	ID          uint            `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Completed   bool            `json:"completed"`
+	UserID      uint            `json:"user_id"`
+	ProjectID   *uint           `json:"project_id"`
+	ParentID    *uint           `json:"parent_id"`
+	Priority    string          `json:"priority"`
+	StartAt     *time.Time      `json:"start_at"`
+	DueAt       *time.Time      `json:"due_at"`
+	Recurrence  string          `json:"recurrence"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+	Tags        []*TagResponse  `json:"tags"`
+	Children    []*TodoResponse `json:"children,omitempty"`
+	Progress    *int            `json:"progress,omitempty"`
 }
 
 // Todoモデルから必要なフィールドだけを取り出すマッパー関数
+// サブタスクが読み込まれている場合は子タスクと進捗率も含めます
 func ToTodoResponse(todo *model.Todo) *TodoResponse {
-	return &TodoResponse{
+	response := &TodoResponse{
 		ID:          todo.ID,
 		Title:       todo.Title,
 		Description: todo.Description,
 		Completed:   todo.Completed,
 		UserID:      todo.UserID,
+		ProjectID:   todo.ProjectID,
+		ParentID:    todo.ParentID,
+		Priority:    todo.Priority.String(),
+		StartAt:     todo.StartAt,
+		DueAt:       todo.DueAt,
+		Recurrence:  todo.Recurrence,
+		DeletedAt:   todo.DeletedAt,
+		Tags:        ToTagResponseList(todo.Tags),
+	}
+	if todo.Children != nil {
+		progress := todo.Progress()
+		response.Children = ToTodoResponseList(todo.Children)
+		response.Progress = &progress
 	}
+
+	return response
 }
 
 // スライス変換用のヘルパー関数
@@ -29,3 +59,25 @@ func ToTodoResponseList(todos []*model.Todo) []*TodoResponse {
 	}
 	return result
 }
+
+// TodoSearchResultResponse は全文検索結果のレスポンスです
+type TodoSearchResultResponse struct {
+	Todo               *TodoResponse `json:"todo"`
+	Rank               float64       `json:"rank"`
+	TitleSnippet       string        `json:"title_snippet"`
+	DescriptionSnippet string        `json:"description_snippet"`
+}
+
+// ToTodoSearchResultResponseList は全文検索結果をレスポンスに変換します
+func ToTodoSearchResultResponseList(results []*model.TodoSearchResult) []*TodoSearchResultResponse {
+	response := make([]*TodoSearchResultResponse, len(results))
+	for i, result := range results {
+		response[i] = &TodoSearchResultResponse{
+			Todo:               ToTodoResponse(result.Todo),
+			Rank:               result.Rank,
+			TitleSnippet:       result.TitleSnippet,
+			DescriptionSnippet: result.DescriptionSnippet,
+		}
+	}
+	return response
+}
@@ -7,6 +7,7 @@ type UserResponse struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 }
 
 // Userモデルから必要なフィールドだけを取り出すマッパー関数
@@ -15,6 +16,7 @@ func ToUserResponse(user *model.User) *UserResponse {
 		ID:       user.ID,
 		Username: user.Username,
 		Email:    user.Email,
+		Role:     string(user.Role),
 	}
 }
 
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// UserSettingsResponse はユーザー設定を表す構造体です
+// WeekStartは曜日の名前（sunday、mondayなど）です
+type UserSettingsResponse struct {
+	Timezone         string `json:"timezone"`
+	Locale           string `json:"locale"`
+	DefaultSort      string `json:"default_sort"`
+	WeekStart        string `json:"week_start"`
+	DefaultProjectID *uint  `json:"default_project_id"`
+}
+
+// UserSettingsモデルから必要なフィールドだけを取り出すマッパー関数
+func ToUserSettingsResponse(settings *model.UserSettings) *UserSettingsResponse {
+	return &UserSettingsResponse{
+		Timezone:         settings.Timezone,
+		Locale:           settings.Locale,
+		DefaultSort:      settings.DefaultSort,
+		WeekStart:        strings.ToLower(settings.WeekStart.String()),
+		DefaultProjectID: settings.DefaultProjectID,
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+)
+
+// OAuthAuthorizationCode はユーザーの同意後に発行する一度だけ使える認可コードです
+// コード自体は保存せず、SHA-256のハッシュ値のみを保持します
+// トークンと交換した際のセッションのFamilyIDを記録し、コードが再び使われた場合にそのトークンを失効させます
+type OAuthAuthorizationCode struct {
+	ID            uint       `json:"id"`
+	CodeHash      string     `json:"-"`
+	OAuthClientID uint       `json:"oauth_client_id" gorm:"column:oauth_client_id"`
+	UserID        uint       `json:"user_id"`
+	RedirectURI   string     `json:"redirect_uri"`
+	Scopes        string     `json:"scopes"`
+	CodeChallenge string     `json:"-"`
+	FamilyID      string     `json:"-"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	UsedAt        *time.Time `json:"used_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TableName はOAuthAuthorizationCodeモデルのテーブル名を返します
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+// NewOAuthAuthorizationCode は新しいOAuthAuthorizationCodeを作成します
+func NewOAuthAuthorizationCode(
+	codeHash string,
+	clientID uint,
+	userID uint,
+	redirectURI string,
+	scopes []Scope,
+	codeChallenge string,
+	expiresAt time.Time,
+) *OAuthAuthorizationCode {
+	return &OAuthAuthorizationCode{
+		CodeHash:      codeHash,
+		OAuthClientID: clientID,
+		UserID:        userID,
+		RedirectURI:   redirectURI,
+		Scopes:        JoinScopes(scopes),
+		CodeChallenge: codeChallenge,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// IsExpired は指定された時刻の時点でコードの有効期限が切れているかどうかを返します
+func (c *OAuthAuthorizationCode) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// OAuthClient はOAuth2の認可コードフローでユーザーの代わりにAPIを使う連携先のクライアントです
+// クライアントシークレットを持つ機密クライアントはシークレットのSHA-256のハッシュ値のみを保持し、
+// 公開クライアントはPKCEのみで認可コードを保護します
+type OAuthClient struct {
+	ID               uint      `json:"id"`
+	ClientID         string    `json:"client_id"`
+	ClientSecretHash string    `json:"-"`
+	Name             string    `json:"name"`
+	RedirectURIs     string    `json:"redirect_uris"`
+	Scopes           string    `json:"scopes"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TableName はOAuthClientモデルのテーブル名を返します
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// NewOAuthClient は新しいOAuthClientを作成します
+// 公開クライアントの場合はclientSecretHashに空文字列を指定します
+func NewOAuthClient(
+	clientID string,
+	clientSecretHash string,
+	name string,
+	redirectURIs []string,
+	scopes []Scope,
+) *OAuthClient {
+	now := time.Now()
+	return &OAuthClient{
+		ClientID:         clientID,
+		ClientSecretHash: clientSecretHash,
+		Name:             name,
+		RedirectURIs:     strings.Join(redirectURIs, " "),
+		Scopes:           JoinScopes(scopes),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// IsConfidential はクライアントシークレットを持つ機密クライアントかどうかを返します
+func (c *OAuthClient) IsConfidential() bool {
+	return c.ClientSecretHash != ""
+}
+
+// RedirectURIList は登録されたリダイレクトURIの一覧を返します
+func (c *OAuthClient) RedirectURIList() []string {
+	return strings.Fields(c.RedirectURIs)
+}
+
+// AllowsRedirectURI は指定されたリダイレクトURIが登録されたものと完全に一致するかどうかを返します
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	for _, registered := range c.RedirectURIList() {
+		if registered == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScopeList はクライアントに許可されたスコープの一覧を返します
+func (c *OAuthClient) ScopeList() []Scope {
+	return SplitScopes(c.Scopes)
+}
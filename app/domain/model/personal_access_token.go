@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+)
+
+// PersonalAccessTokenPrefix は個人用アクセストークンの先頭に付ける文字列です
+// JWTと区別し、漏洩したトークンをスキャンで検出しやすくするために使います
+const PersonalAccessTokenPrefix = "tdp_"
+
+// PersonalAccessToken はスクリプトやCIからAPIを使うための個人用アクセストークンです
+// トークン自体は保存せず、SHA-256のハッシュ値と、一覧で見分けるための先頭部分のみを保持します
+type PersonalAccessToken struct {
+	ID          uint       `json:"id"`
+	UserID      uint       `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"-"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      string     `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName はPersonalAccessTokenモデルのテーブル名を返します
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// NewPersonalAccessToken は新しいPersonalAccessTokenを作成します
+func NewPersonalAccessToken(
+	userID uint,
+	name string,
+	tokenHash string,
+	tokenPrefix string,
+	scopes []Scope,
+	expiresAt *time.Time,
+) *PersonalAccessToken {
+	return &PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   tokenHash,
+		TokenPrefix: tokenPrefix,
+		Scopes:      JoinScopes(scopes),
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// ScopeList はトークンに付与されたスコープの一覧を返します
+func (t *PersonalAccessToken) ScopeList() []Scope {
+	return SplitScopes(t.Scopes)
+}
+
+// HasScope はトークンに指定されたスコープが付与されているかどうかを返します
+func (t *PersonalAccessToken) HasScope(scope Scope) bool {
+	return ContainsScope(t.ScopeList(), scope)
+}
+
+// IsExpired は指定された時刻の時点でトークンの有効期限が切れているかどうかを返します
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Priority はTodoの優先度を表します
+// 値が大きいほど優先度が高くなります
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = map[Priority]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+// ParsePriority は文字列から優先度を取得します
+func ParsePriority(name string) (Priority, error) {
+	for priority, priorityName := range priorityNames {
+		if priorityName == name {
+			return priority, nil
+		}
+	}
+
+	return PriorityNone, fmt.Errorf("無効な優先度です: %s", name)
+}
+
+// String は優先度の名前を返します
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+
+	return priorityNames[PriorityNone]
+}
+
+// MarshalJSON は優先度を名前の文字列としてJSONに変換します
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
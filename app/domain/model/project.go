@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+)
+
+type Project struct {
+	ID                 uint      `json:"id"`
+	Name               string    `json:"name"`
+	UserID             uint      `json:"user_id"`
+	Archived           bool      `json:"archived"`
+	OpenTodoCount      int64     `json:"open_todo_count" gorm:"-"`
+	CompletedTodoCount int64     `json:"completed_todo_count" gorm:"-"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ProjectTodoCount はプロジェクトごとの未完了・完了済みTodoの件数です
+type ProjectTodoCount struct {
+	ProjectID      uint
+	OpenCount      int64
+	CompletedCount int64
+}
+
+// TableName はProjectモデルのテーブル名を返します
+func (Project) TableName() string {
+	return "projects"
+}
+
+// NewProject は新しいProjectを作成します
+func NewProject(name string, userID uint) *Project {
+	now := time.Now()
+	return &Project{
+		Name:      name,
+		UserID:    userID,
+		Archived:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Rename はプロジェクトの名前を変更します
+func (p *Project) Rename(name string) {
+	p.Name = name
+	p.UpdatedAt = time.Now()
+}
+
+// SetArchived はプロジェクトのアーカイブ状態を変更します
+func (p *Project) SetArchived(archived bool) {
+	p.Archived = archived
+	p.UpdatedAt = time.Now()
+}
+
+// SetTodoCount はプロジェクトのTodo件数を設定します
+func (p *Project) SetTodoCount(count *ProjectTodoCount) {
+	if count == nil {
+		p.OpenTodoCount = 0
+		p.CompletedTodoCount = 0
+		return
+	}
+	p.OpenTodoCount = count.OpenCount
+	p.CompletedTodoCount = count.CompletedCount
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// RefreshToken はアクセストークンの再発行に使うリフレッシュトークンです
+// トークン自体は保存せず、SHA-256のハッシュ値のみを保持します
+// 同じログインから再発行されたトークンは同じFamilyIDを持ち、再利用を検出した際にまとめて失効させます
+type RefreshToken struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName はRefreshTokenモデルのテーブル名を返します
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// NewRefreshToken は新しいRefreshTokenを作成します
+func NewRefreshToken(userID uint, familyID, tokenHash string, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsUsable は指定された時刻の時点でトークンが未使用かつ有効かどうかを返します
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
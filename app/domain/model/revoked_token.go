@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// RevokedToken は有効期限前に失効させたアクセストークンです
+// トークンのjtiクレームで識別し、有効期限を過ぎたものは削除できます
+type RevokedToken struct {
+	JTI       string    `json:"jti" gorm:"column:jti;primaryKey"`
+	UserID    uint      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// TableName はRevokedTokenモデルのテーブル名を返します
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// NewRevokedToken は新しいRevokedTokenを作成します
+func NewRevokedToken(jti string, userID uint, expiresAt time.Time) *RevokedToken {
+	return &RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		RevokedAt: time.Now(),
+	}
+}
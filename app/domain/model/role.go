@@ -0,0 +1,26 @@
+package model
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// Role はユーザーの権限です
+type Role string
+
+const (
+	// RoleUser は自分のデータのみを操作できる一般ユーザーです
+	RoleUser Role = "user"
+	// RoleAdmin は全てのユーザーとTodoを操作できる管理者です
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole は文字列からRoleを取得します
+func ParseRole(value string) (Role, error) {
+	switch role := Role(value); role {
+	case RoleUser, RoleAdmin:
+		return role, nil
+	default:
+		return "", domainerr.InvalidField("role", i18n.InvalidRole, value)
+	}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// Scope は個人用アクセストークンに付与する権限の範囲です
+type Scope string
+
+const (
+	// ScopeTodosRead はTodo、タグ、プロジェクトの参照を許可します
+	ScopeTodosRead Scope = "todos:read"
+	// ScopeTodosWrite はTodo、タグ、プロジェクトの作成、更新、削除を許可します
+	ScopeTodosWrite Scope = "todos:write"
+	// ScopeUsersAdmin は管理者向けのユーザー管理を許可します。管理者のみ付与できます
+	ScopeUsersAdmin Scope = "users:admin"
+)
+
+// ParseScopes は文字列のスコープの一覧を検証し、重複を除いたScopeの一覧を返します
+func ParseScopes(values []string) ([]Scope, error) {
+	if len(values) == 0 {
+		return nil, domainerr.InvalidField("scopes", i18n.ScopesRequired)
+	}
+	scopes := make([]Scope, 0, len(values))
+	for _, value := range values {
+		scope := Scope(value)
+		switch scope {
+		case ScopeTodosRead, ScopeTodosWrite, ScopeUsersAdmin:
+		default:
+			return nil, domainerr.InvalidField("scopes", i18n.InvalidScope, value)
+		}
+		if !ContainsScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, nil
+}
+
+// JoinScopes はスコープの一覧を保存用の空白区切りの文字列にします
+func JoinScopes(scopes []Scope) string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+
+	return strings.Join(values, " ")
+}
+
+// SplitScopes は空白区切りの文字列をスコープの一覧にします
+func SplitScopes(value string) []Scope {
+	fields := strings.Fields(value)
+	scopes := make([]Scope, len(fields))
+	for i, field := range fields {
+		scopes[i] = Scope(field)
+	}
+
+	return scopes
+}
+
+// ContainsScope はスコープの一覧に指定されたスコープが含まれるかどうかを返します
+func ContainsScope(scopes []Scope, scope Scope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
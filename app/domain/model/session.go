@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// sessionUserAgentMaxLength はセッションに記録するユーザーエージェントの最大文字数です
+const sessionUserAgentMaxLength = 255
+
+// Session はログインごとに作成されるセッションです
+// 同じログインから発行されたリフレッシュトークンとFamilyIDで対応付けられます
+// OAuthクライアントに認可した場合もセッションを作成し、認可したクライアントとスコープを記録します
+type Session struct {
+	ID            uint       `json:"id"`
+	UserID        uint       `json:"user_id"`
+	FamilyID      string     `json:"-"`
+	OAuthClientID *uint      `json:"oauth_client_id" gorm:"column:oauth_client_id"`
+	Scopes        string     `json:"scopes"`
+	UserAgent     string     `json:"user_agent"`
+	IPAddress     string     `json:"ip_address"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastSeenAt    time.Time  `json:"last_seen_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+}
+
+// TableName はSessionモデルのテーブル名を返します
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// NewSession は新しいSessionを作成します
+// ユーザーエージェントが長すぎる場合は切り詰めます
+func NewSession(userID uint, familyID, userAgent, ipAddress string) *Session {
+	if utf8.RuneCountInString(userAgent) > sessionUserAgentMaxLength {
+		userAgent = string([]rune(userAgent)[:sessionUserAgentMaxLength])
+	}
+	now := time.Now()
+	return &Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+}
+
+// NewOAuthSession はOAuthクライアントへの認可で作成するSessionを作成します
+// セッションで発行するトークンは認可されたスコープの操作のみ行えます
+func NewOAuthSession(userID uint, familyID string, clientID uint, scopes []Scope, userAgent, ipAddress string) *Session {
+	session := NewSession(userID, familyID, userAgent, ipAddress)
+	session.OAuthClientID = &clientID
+	session.Scopes = JoinScopes(scopes)
+
+	return session
+}
+
+// IsDelegated はOAuthクライアントに認可したセッションなど、操作がスコープで制限されたセッションかどうかを返します
+func (s *Session) IsDelegated() bool {
+	return s.Scopes != ""
+}
+
+// ScopeList はセッションで認可されたスコープの一覧を返します
+func (s *Session) ScopeList() []Scope {
+	return SplitScopes(s.Scopes)
+}
+
+// IsRevoked はセッションが終了しているかどうかを返します
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
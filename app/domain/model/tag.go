@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+type Tag struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName はTagモデルのテーブル名を返します
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// NewTag は新しいTagを作成します
+func NewTag(name string, userID uint) *Tag {
+	now := time.Now()
+	return &Tag{
+		Name:      name,
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Rename はタグの名前を変更します
+func (t *Tag) Rename(name string) {
+	t.Name = name
+	t.UpdatedAt = time.Now()
+}
@@ -5,13 +5,33 @@ import (
 )
 
 type Todo struct {
-	ID          uint      `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	UserID      uint      `json:"user_id"` // 追加: ユーザーIDフィールド
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              uint       `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Completed       bool       `json:"completed"`
+	UserID          uint       `json:"user_id"` // 追加: ユーザーIDフィールド
+	ProjectID       *uint      `json:"project_id"`
+	ParentID        *uint      `json:"parent_id"`
+	Priority        Priority   `json:"priority"`
+	StartAt         *time.Time `json:"start_at"`
+	DueAt           *time.Time `json:"due_at"`
+	Recurrence      string     `json:"recurrence"`
+	RecurrenceStart *time.Time `json:"recurrence_start"`
+	Tags            []*Tag     `json:"tags" gorm:"many2many:todo_tags;"`
+	Children        []*Todo    `json:"children,omitempty" gorm:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeleteFlag      bool       `json:"delete_flag"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+}
+
+// TodoSearchResult は全文検索に一致したTodoです
+// Rankは検索語との関連度、スニペットはHTMLエスケープした上で一致した語を<mark>タグで囲んだ抜粋です
+type TodoSearchResult struct {
+	Todo               *Todo
+	Rank               float64
+	TitleSnippet       string
+	DescriptionSnippet string
 }
 
 // TableName はTodoモデルのテーブル名を返します
@@ -44,3 +64,80 @@ func (t *Todo) UpdateTitle(title string, description string) {
 	t.Description = description
 	t.UpdatedAt = time.Now()
 }
+
+// UpdateSchedule はタスクの開始日時と期限を更新します
+func (t *Todo) UpdateSchedule(startAt *time.Time, dueAt *time.Time) {
+	t.StartAt = startAt
+	t.DueAt = dueAt
+	t.UpdatedAt = time.Now()
+}
+
+// UpdatePriority はタスクの優先度を更新します
+func (t *Todo) UpdatePriority(priority Priority) {
+	t.Priority = priority
+	t.UpdatedAt = time.Now()
+}
+
+// MoveToProject はタスクを指定されたプロジェクトに移動します
+// nilを指定するとプロジェクトに属さないタスクになります
+func (t *Todo) MoveToProject(projectID *uint) {
+	t.ProjectID = projectID
+	t.UpdatedAt = time.Now()
+}
+
+// SetRecurrence はタスクの繰り返しルール（RFC 5545のRRULE形式）と基準日時を設定します
+// 基準日時は繰り返しの最初の期限です。空のルールを指定すると繰り返しを解除します
+func (t *Todo) SetRecurrence(rule string, start *time.Time) {
+	t.Recurrence = rule
+	t.RecurrenceStart = start
+	if rule == "" {
+		t.RecurrenceStart = nil
+	}
+	t.UpdatedAt = time.Now()
+}
+
+// HasOpenChildren は未完了のサブタスクが存在するかどうかを返します
+func (t *Todo) HasOpenChildren() bool {
+	for _, child := range t.Children {
+		if !child.Completed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Progress はサブタスクを含めた完了率をパーセントで返します
+// サブタスクがない場合は自身の完了状態に応じて0または100を返します
+func (t *Todo) Progress() int {
+	total, completed := t.countDescendants()
+	if total == 0 {
+		if t.Completed {
+			return 100
+		}
+		return 0
+	}
+
+	return completed * 100 / total
+}
+
+// countDescendants は全ての子孫タスクの件数と完了済みの件数を返します
+func (t *Todo) countDescendants() (int, int) {
+	total, completed := 0, 0
+	for _, child := range t.Children {
+		total++
+		if child.Completed {
+			completed++
+		}
+		childTotal, childCompleted := child.countDescendants()
+		total += childTotal
+		completed += childCompleted
+	}
+
+	return total, completed
+}
+
+// IsOverdue は指定された時刻の時点でタスクが期限切れかどうかを返します
+func (t *Todo) IsOverdue(now time.Time) bool {
+	return !t.Completed && t.DueAt != nil && t.DueAt.Before(now)
+}
@@ -5,13 +5,17 @@ import (
 )
 
 type User struct {
-	ID         uint      `json:"id"`
-	Username   string    `json:"username"`
-	Password   string    `json:"password"`
-	Email      string    `json:"email"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	DeleteFlag bool      `json:"delete_flag"`
+	ID               uint       `json:"id"`
+	Username         string     `json:"username"`
+	Password         string     `json:"password"`
+	PasswordPepperID string     `json:"password_pepper_id"`
+	Email            string     `json:"email"`
+	Role             Role       `json:"role"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeleteFlag       bool       `json:"delete_flag"`
+	DeactivatedAt    *time.Time `json:"deactivated_at"`
+	TokensRevokedAt  *time.Time `json:"tokens_revoked_at"`
 }
 
 func (User) TableName() string {
@@ -21,14 +25,41 @@ func (User) TableName() string {
 func NewUser(
 	Username string,
 	Password string,
+	PasswordPepperID string,
 	Email string,
 ) *User {
 	now := time.Now()
 	return &User{
-		Username:  Username,
-		Password:  Password,
-		Email:     Email,
-		CreatedAt: now,
-		UpdatedAt: now,
+		Username:         Username,
+		Password:         Password,
+		PasswordPepperID: PasswordPepperID,
+		Email:            Email,
+		Role:             RoleUser,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 }
+
+// TokenIssuedBeforeRevocation は指定された時刻に発行されたトークンが一括失効の対象かどうかを返します
+// JWTの発行日時は秒単位のため、失効と同じ秒に発行されたトークンも失効の対象とします
+func (u *User) TokenIssuedBeforeRevocation(issuedAt time.Time) bool {
+	return u.TokensRevokedAt != nil && !issuedAt.Truncate(time.Second).After(u.TokensRevokedAt.Truncate(time.Second))
+}
+
+// IsAdmin はユーザーが管理者かどうかを返します
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// ChangeRole はユーザーのロールを変更します
+func (u *User) ChangeRole(role Role) {
+	u.Role = role
+	u.UpdatedAt = time.Now()
+}
+
+// ChangePassword はユーザーのパスワードをハッシュ化済みの値と、そのハッシュ化に使用したペッパーのキーIDに変更します
+func (u *User) ChangePassword(hashedPassword, pepperID string) {
+	u.Password = hashedPassword
+	u.PasswordPepperID = pepperID
+	u.UpdatedAt = time.Now()
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultTimezone はタイムゾーンが設定されていない場合に使用するタイムゾーンです
+const DefaultTimezone = "UTC"
+
+// UserSettings はユーザーごとの言語、タイムゾーン、一覧表示の設定です
+// Localeが空の場合はAccept-Languageヘッダーから言語を決定し、DefaultSortが空の場合は一覧の既定の並び順を使います
+type UserSettings struct {
+	UserID           uint         `json:"user_id" gorm:"primaryKey"`
+	Timezone         string       `json:"timezone"`
+	Locale           string       `json:"locale"`
+	DefaultSort      string       `json:"default_sort"`
+	WeekStart        time.Weekday `json:"week_start"`
+	DefaultProjectID *uint        `json:"default_project_id"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// TableName はUserSettingsモデルのテーブル名を返します
+func (UserSettings) TableName() string {
+	return "user_settings"
+}
+
+// NewUserSettings は既定値の設定を作成します
+// 週の始まりは月曜日です
+func NewUserSettings(userID uint) *UserSettings {
+	return &UserSettings{
+		UserID:    userID,
+		Timezone:  DefaultTimezone,
+		WeekStart: time.Monday,
+	}
+}
+
+// Location はユーザーのタイムゾーンを返します。読み込めない場合はUTCを返します
+func (s *UserSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// StartOfDay は指定された日時を含む日の開始日時をユーザーのタイムゾーンで返します
+func (s *UserSettings) StartOfDay(t time.Time) time.Time {
+	t = t.In(s.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek は指定された日時を含む週の開始日時をユーザーのタイムゾーンと週の始まりに従って返します
+func (s *UserSettings) StartOfWeek(t time.Time) time.Time {
+	day := s.StartOfDay(t)
+	offset := (int(day.Weekday()) - int(s.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// ParseWeekday は曜日の名前（sunday、mondayなど）から曜日を取得します
+func ParseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+
+	return time.Sunday, false
+}
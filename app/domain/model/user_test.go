@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenIssuedBeforeRevocation(t *testing.T) {
+	revokedAt := time.Date(2024, 1, 1, 9, 0, 0, 700_000_000, time.UTC)
+	tests := []struct {
+		name      string
+		revokedAt *time.Time
+		issuedAt  time.Time
+		want      bool
+	}{
+		{"一括失効していない", nil, revokedAt, false},
+		{"失効より前に発行", &revokedAt, revokedAt.Add(-time.Second), true},
+		{"失効と同じ秒の前に発行", &revokedAt, revokedAt.Add(-300 * time.Millisecond), true},
+		{"失効と同時に発行", &revokedAt, revokedAt, true},
+		{"失効と同じ秒の後に発行", &revokedAt, revokedAt.Add(200 * time.Millisecond), true},
+		{"失効の次の秒に発行", &revokedAt, revokedAt.Add(300 * time.Millisecond), false},
+		{"失効より後に発行", &revokedAt, revokedAt.Add(time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{TokensRevokedAt: tt.revokedAt}
+			if got := user.TokenIssuedBeforeRevocation(tt.issuedAt); got != tt.want {
+				t.Errorf("TokenIssuedBeforeRevocation(%s) = %v, want %v", tt.issuedAt, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+var (
+	// ErrInvalidCursor はカーソルが不正な場合や並び順と一致しない場合に返されます
+	ErrInvalidCursor = domainerr.InvalidField("cursor", i18n.InvalidCursor)
+	// ErrInvalidSort は指定された並び順に対応していない場合に返されます
+	ErrInvalidSort = domainerr.InvalidField("sort", i18n.InvalidSort)
+)
+
+// TodoSorts はTodo一覧で指定できる並び順の名前です。先頭に"-"を付けると降順になります
+var TodoSorts = []string{"id", "created_at", "updated_at", "due_at", "priority"}
+
+// IsTodoSort はTodo一覧で指定できる並び順かどうかを返します
+func IsTodoSort(name string) bool {
+	return slices.Contains(TodoSorts, strings.TrimPrefix(name, "-"))
+}
+
+// ListQuery は一覧取得時のページングと並び順の条件です
+// Cursorは前のページのNextCursorで、空の場合は最初のページを取得します
+// Sortは並び順の名前で、先頭に"-"を付けると降順になります
+type ListQuery struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+// TimeRange は日時の範囲条件です
+// Fromは指定日時以降、Toは指定日時より前を表し、nilの場合は制限しません
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// TodoFilter はTodo一覧の絞り込み条件です
+type TodoFilter struct {
+	UserID       *uint
+	ProjectID    *uint
+	Completed    *bool
+	Created      TimeRange
+	Updated      TimeRange
+	Due          TimeRange
+	Text         string
+	Tags         []string
+	MatchAllTags bool
+}
+
+// UserFilter はユーザー一覧の絞り込み条件です
+type UserFilter struct {
+	Created TimeRange
+	Updated TimeRange
+	Text    string
+}
+
+// TodoPage はTodo一覧の1ページ分の結果です
+// NextCursorは次のページがない場合は空になります
+type TodoPage struct {
+	Todos      []*model.Todo
+	NextCursor string
+	Total      int64
+}
+
+// UserPage はユーザー一覧の1ページ分の結果です
+// NextCursorは次のページがない場合は空になります
+type UserPage struct {
+	Users      []*model.User
+	NextCursor string
+	Total      int64
+}
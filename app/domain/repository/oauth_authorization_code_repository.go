@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// OAuthAuthorizationCodeRepository は認可コードの永続化を担当するインターフェース
+type OAuthAuthorizationCodeRepository interface {
+	FindByCodeHash(codeHash string) (*model.OAuthAuthorizationCode, error)
+	Create(code *model.OAuthAuthorizationCode) error
+	MarkUsed(id uint, familyID string, usedAt time.Time) (bool, error)
+	PurgeExpiredBefore(before time.Time) (int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// OAuthClientRepository はOAuthクライアントの永続化を担当するインターフェース
+type OAuthClientRepository interface {
+	FindAll() ([]*model.OAuthClient, error)
+	FindByID(id uint) (*model.OAuthClient, error)
+	FindByClientID(clientID string) (*model.OAuthClient, error)
+	Create(client *model.OAuthClient) error
+	Delete(id uint) error
+}
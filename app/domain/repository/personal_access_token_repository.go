@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// PersonalAccessTokenRepository は個人用アクセストークンの永続化を担当するインターフェース
+type PersonalAccessTokenRepository interface {
+	FindByID(id uint) (*model.PersonalAccessToken, error)
+	FindByTokenHash(tokenHash string) (*model.PersonalAccessToken, error)
+	FindByUserID(userID uint) ([]*model.PersonalAccessToken, error)
+	Create(token *model.PersonalAccessToken) error
+	Touch(id uint, usedAt time.Time) error
+	Delete(id uint) error
+}
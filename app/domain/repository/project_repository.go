@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/jugeeem/golang-todo.git/app/domain/model"
+
+// ProjectRepository はプロジェクトの永続化を担当するインターフェース
+type ProjectRepository interface {
+	FindByID(id uint) (*model.Project, error)
+	FindByUserID(userID uint, includeArchived bool) ([]*model.Project, error)
+	FindByUserIDAndName(userID uint, name string) (*model.Project, error)
+	CountTodosByUserID(userID uint) ([]*model.ProjectTodoCount, error)
+	Create(project *model.Project) error
+	Update(project *model.Project) error
+	Delete(id uint) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// RefreshTokenRepository はリフレッシュトークンの永続化を担当するインターフェース
+type RefreshTokenRepository interface {
+	FindByTokenHash(tokenHash string) (*model.RefreshToken, error)
+	Create(token *model.RefreshToken) error
+	MarkUsed(id uint, usedAt time.Time) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeByUserIDBefore(userID uint, before time.Time) error
+	PurgeExpiredBefore(before time.Time) (int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// RevokedTokenRepository は失効させたアクセストークンの永続化を担当するインターフェース
+type RevokedTokenRepository interface {
+	Revoke(token *model.RevokedToken) error
+	IsRevoked(jti string) (bool, error)
+	PurgeExpiredBefore(before time.Time) (int64, error)
+}
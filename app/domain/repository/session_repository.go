@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// SessionRepository はセッションの永続化を担当するインターフェース
+type SessionRepository interface {
+	FindByID(id uint) (*model.Session, error)
+	FindByFamilyID(familyID string) (*model.Session, error)
+	FindActiveByUserID(userID uint, seenAfter time.Time) ([]*model.Session, error)
+	Create(session *model.Session) error
+	Touch(id uint, seenAt time.Time) error
+	Revoke(id uint) error
+	RevokeByUserIDBefore(userID uint, before time.Time) error
+	PurgeInactiveBefore(before time.Time) (int64, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/jugeeem/golang-todo.git/app/domain/model"
+
+// TagRepository はタグの永続化を担当するインターフェース
+type TagRepository interface {
+	FindByID(id uint) (*model.Tag, error)
+	FindByUserID(userID uint) ([]*model.Tag, error)
+	FindByUserIDAndName(userID uint, name string) (*model.Tag, error)
+	Create(tag *model.Tag) error
+	Update(tag *model.Tag) error
+	Delete(id uint) error
+	AttachToTodo(todoID uint, tagID uint) error
+	DetachFromTodo(todoID uint, tagID uint) error
+}
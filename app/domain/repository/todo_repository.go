@@ -1,13 +1,25 @@
 package repository
 
-import "github.com/jugeeem/golang-todo.git/app/domain/model"
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
 
 // TodoRepository はTodoの永続化を担当するインターフェース
 type TodoRepository interface {
 	FindByID(id uint) (*model.Todo, error)
 	FindAll() ([]*model.Todo, error)
 	FindByUserID(userID uint) ([]*model.Todo, error)
+	FindByParentID(parentID uint) ([]*model.Todo, error)
+	FindPage(filter TodoFilter, query ListQuery) (*TodoPage, error)
+	Search(userID uint, text string, limit int) ([]*model.TodoSearchResult, error)
 	Create(todo *model.Todo) error
 	Update(todo *model.Todo) error
+	FindTrashedByID(id uint) (*model.Todo, error)
+	FindTrashedByUserID(userID uint) ([]*model.Todo, error)
 	Delete(id uint) error
+	Restore(id uint) error
+	HardDelete(id uint) error
+	PurgeTrashedBefore(before time.Time) (int64, error)
 }
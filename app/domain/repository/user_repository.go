@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 )
 
@@ -14,7 +16,14 @@ type UserRepository interface {
 	FindByUsernameOrEmail(username, email string) (*model.User, error)
 	FindByUsernameAndEmail(username, email string) (*model.User, error)
 	FindAll() ([]*model.User, error)
+	FindPage(filter UserFilter, query ListQuery) (*UserPage, error)
+	FindDeactivatedByID(id uint) (*model.User, error)
+	FindByUsernameOrEmailWithDeactivated(username, email string) (*model.User, error)
 	Create(user *model.User) (*model.User, error)
 	Update(user *model.User) (*model.User, error)
-	Remove(id uint) error
+	Deactivate(id uint) error
+	Reactivate(id uint) error
+	RevokeTokensBefore(id uint, before time.Time) error
+	Purge(id uint) error
+	PurgeDeactivatedBefore(before time.Time) (int64, error)
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/jugeeem/golang-todo.git/app/domain/model"
+
+// UserSettingsRepository はユーザー設定の永続化を担当するインターフェース
+type UserSettingsRepository interface {
+	FindByUserID(userID uint) (*model.UserSettings, error)
+	Save(settings *model.UserSettings) error
+}
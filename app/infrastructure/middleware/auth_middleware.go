@@ -1,38 +1,172 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
 	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
+// sessionTouchInterval はセッションの最終利用日時を更新する最小間隔です
+const sessionTouchInterval = time.Minute
+
 // JWTAuthMiddleware はJWT認証を行うミドルウェアです
-func JWTAuthMiddleware() gin.HandlerFunc {
+// トークンが有効でも、ログアウトなどで失効している場合、セッションが終了している場合、
+// ユーザーが無効化または削除されている場合は拒否します
+// tdp_で始まるトークンは個人用アクセストークンとして認証します
+func JWTAuthMiddleware(
+	userRepo repository.UserRepository,
+	revokedTokenRepo repository.RevokedTokenRepository,
+	sessionRepo repository.SessionRepository,
+	accessTokenRepo repository.PersonalAccessTokenRepository,
+) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証ヘッダーがありません"})
+			c.Error(domainerr.Unauthorized(i18n.AuthHeaderMissing))
 			c.Abort()
 			return
 		}
 		parts := strings.SplitN(authHeader, " ", 2)
 		if !(len(parts) == 2 && parts[0] == "Bearer") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "認証形式が不正です"})
+			c.Error(domainerr.Unauthorized(i18n.AuthHeaderMalformed))
 			c.Abort()
 			return
 		}
 		tokenString := parts[1]
+		if strings.HasPrefix(tokenString, model.PersonalAccessTokenPrefix) {
+			if authenticatePersonalAccessToken(c, tokenString, userRepo, accessTokenRepo) {
+				c.Next()
+			}
+			return
+		}
 		claims, err := utility.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "無効なトークン: " + err.Error()})
+			c.Error(domainerr.Unauthorized(i18n.TokenInvalid).Wrap(err))
+			c.Abort()
+			return
+		}
+		if claims.ID == "" || claims.IssuedAt == nil || claims.SessionID == 0 {
+			c.Error(domainerr.Unauthorized(i18n.TokenClaimsMissing))
+			c.Abort()
+			return
+		}
+		revoked, err := revokedTokenRepo.IsRevoked(claims.ID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.Error(domainerr.Unauthorized(i18n.TokenRevoked))
+			c.Abort()
+			return
+		}
+		session, err := sessionRepo.FindByID(claims.SessionID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if session == nil || session.IsRevoked() || session.UserID != claims.UserID {
+			c.Error(domainerr.Unauthorized(i18n.SessionEnded))
+			c.Abort()
+			return
+		}
+		if session.IsDelegated() {
+			c.Set("scopes", session.ScopeList())
+		}
+		if now := time.Now(); now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+			if err := sessionRepo.Touch(session.ID, now); err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+		}
+		user, err := userRepo.FindByID(claims.UserID)
+		if err != nil {
+			c.Error(err)
 			c.Abort()
 			return
 		}
+		if user == nil {
+			c.Error(domainerr.Unauthorized(i18n.AccountDisabled))
+			c.Abort()
+			return
+		}
+		if user.TokenIssuedBeforeRevocation(claims.IssuedAt.Time) {
+			c.Error(domainerr.Unauthorized(i18n.TokenRevoked))
+			c.Abort()
+			return
+		}
+		// ロールの変更をすぐに反映するため、権限の判定にはトークンのroleクレームではなく最新のユーザー情報を使います
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("role", user.Role)
+		c.Set("claims", claims)
 
 		c.Next()
 	}
 }
+
+// authenticatePersonalAccessToken は個人用アクセストークンを検証し、認証済みのユーザーとトークンをコンテキストに設定します
+// 有効期限が切れている場合や、全ての端末からのログアウトなどで一括失効した後に作成されたトークンでない場合は拒否します
+// 認証に失敗した場合はエラーを設定してリクエストを中断し、falseを返します
+func authenticatePersonalAccessToken(
+	c *gin.Context,
+	tokenString string,
+	userRepo repository.UserRepository,
+	accessTokenRepo repository.PersonalAccessTokenRepository,
+) bool {
+	token, err := accessTokenRepo.FindByTokenHash(utility.HashToken(tokenString))
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return false
+	}
+	if token == nil {
+		c.Error(domainerr.Unauthorized(i18n.TokenInvalid))
+		c.Abort()
+		return false
+	}
+	now := time.Now()
+	if token.IsExpired(now) {
+		c.Error(domainerr.Unauthorized(i18n.AccessTokenExpired))
+		c.Abort()
+		return false
+	}
+	user, err := userRepo.FindByID(token.UserID)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return false
+	}
+	if user == nil {
+		c.Error(domainerr.Unauthorized(i18n.AccountDisabled))
+		c.Abort()
+		return false
+	}
+	if user.TokenIssuedBeforeRevocation(token.CreatedAt) {
+		c.Error(domainerr.Unauthorized(i18n.TokenRevoked))
+		c.Abort()
+		return false
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= sessionTouchInterval {
+		if err := accessTokenRepo.Touch(token.ID, now); err != nil {
+			c.Error(err)
+			c.Abort()
+			return false
+		}
+	}
+	c.Set("userID", user.ID)
+	c.Set("username", user.Username)
+	c.Set("role", user.Role)
+	c.Set("scopes", token.ScopeList())
+
+	return true
+}
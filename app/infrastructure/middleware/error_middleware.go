@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// problemType はエラーの種類ごとのレスポンスの内容です
+type problemType struct {
+	uri    string
+	title  i18n.MessageID
+	status int
+}
+
+// problemTypes はエラーの種類とRFC 7807のtype、title、HTTPステータスコードの対応です
+var problemTypes = map[domainerr.Kind]problemType{
+	domainerr.KindNotFound:     {"/problems/not-found", i18n.ProblemNotFound, http.StatusNotFound},
+	domainerr.KindForbidden:    {"/problems/forbidden", i18n.ProblemForbidden, http.StatusForbidden},
+	domainerr.KindConflict:     {"/problems/conflict", i18n.ProblemConflict, http.StatusConflict},
+	domainerr.KindValidation:   {"/problems/validation-error", i18n.ProblemValidation, http.StatusBadRequest},
+	domainerr.KindUnauthorized: {"/problems/unauthorized", i18n.ProblemUnauthorized, http.StatusUnauthorized},
+}
+
+// internalProblemType は種類が特定されていないエラーのレスポンスの内容です
+var internalProblemType = problemType{"about:blank", i18n.ProblemInternal, http.StatusInternalServerError}
+
+// ErrorHandler はハンドラーやミドルウェアがc.Errorで登録したエラーをRFC 7807形式（application/problem+json）のレスポンスに変換するミドルウェアです
+// エラーの種類からtypeとHTTPステータスコードを決定し、メッセージはリクエストの言語に翻訳します。種類が特定されていないエラーは内容をログに出力し、
+// クライアントには詳細を隠して500を返します。種類付きのエラーが原因となったエラーを保持している場合も、原因はログにのみ出力します
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+		lang := GetLanguage(c)
+		problem := &dto.ProblemResponse{Instance: c.Request.URL.Path}
+		pt := internalProblemType
+		var domainErr *domainerr.Error
+		if errors.As(err, &domainErr) && domainErr.Kind != domainerr.KindInternal {
+			pt = problemTypes[domainErr.Kind]
+			problem.Detail = domainErr.Localize(lang)
+			problem.Errors = dto.ToProblemFieldErrorList(domainErr.Fields, lang)
+			if domainErr.Err != nil {
+				// 原因となったエラーはクライアントに返さず、調査できるようログにのみ出力します
+				log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, domainErr)
+			}
+		} else {
+			log.Printf("内部エラー: %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+		problem.Type = pt.uri
+		problem.Title = i18n.Translate(lang, pt.title)
+		problem.Status = pt.status
+
+		c.Header("Content-Type", dto.ProblemContentType)
+		c.JSON(pt.status, problem)
+	}
+}
+
+// NoRouteHandler は存在しないエンドポイントへのリクエストをRFC 7807形式のエラーにします
+func NoRouteHandler(c *gin.Context) {
+	c.Error(domainerr.NotFound(i18n.EndpointNotFound))
+}
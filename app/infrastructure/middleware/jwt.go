@@ -4,13 +4,20 @@ import (
 	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
+// errAuthRequired は認証済みのユーザーの情報がコンテキストにない場合に返されます
+var errAuthRequired = domainerr.Unauthorized(i18n.AuthRequired)
+
 // GetUserID はコンテキストからユーザーIDを取得します
 func GetUserID(c *gin.Context) (uint, error) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		return 0, errors.New("ユーザーIDが見つかりません")
+		return 0, errAuthRequired
 	}
 	id, ok := userID.(uint)
 	if !ok {
@@ -19,3 +26,43 @@ func GetUserID(c *gin.Context) (uint, error) {
 
 	return id, nil
 }
+
+// GetClaims はコンテキストから検証済みのJWTクレームを取得します
+func GetClaims(c *gin.Context) (*utility.JWTClaims, error) {
+	value, exists := c.Get("claims")
+	if !exists {
+		return nil, errAuthRequired
+	}
+	claims, ok := value.(*utility.JWTClaims)
+	if !ok {
+		return nil, errors.New("トークンの情報の型が無効です")
+	}
+
+	return claims, nil
+}
+
+// GetRole はコンテキストからユーザーのロールを取得します
+func GetRole(c *gin.Context) (model.Role, error) {
+	value, exists := c.Get("role")
+	if !exists {
+		return "", errAuthRequired
+	}
+	role, ok := value.(model.Role)
+	if !ok {
+		return "", errors.New("ロールの型が無効です")
+	}
+
+	return role, nil
+}
+
+// GetScopes はリクエストが個人用アクセストークンやOAuthのアクセストークンで認証された場合に、許可されたスコープを返します
+// ログインで発行されたアクセストークンで認証された場合はスコープによる制限がないため、falseを返します
+func GetScopes(c *gin.Context) ([]model.Scope, bool) {
+	value, exists := c.Get("scopes")
+	if !exists {
+		return nil, false
+	}
+	scopes, ok := value.([]model.Scope)
+
+	return scopes, ok
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// languageKey はレスポンスの言語を保存するコンテキストのキーです
+const languageKey = "language"
+
+// Localization はAccept-Languageヘッダーからレスポンスの言語を決定するミドルウェアです
+func Localization() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		SetLanguage(c, i18n.Negotiate(c.GetHeader("Accept-Language")))
+
+		c.Next()
+	}
+}
+
+// UserLocale はユーザー設定で言語が指定されている場合に、Accept-Languageヘッダーより優先してレスポンスの言語にするミドルウェアです
+// JWTAuthMiddlewareの後に使用します
+func UserLocale(settingsRepo repository.UserSettingsRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetUserID(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		settings, err := settingsRepo.FindByUserID(userID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if settings != nil {
+			if lang, ok := i18n.ParseLanguage(settings.Locale); ok {
+				SetLanguage(c, lang)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// SetLanguage はレスポンスの言語を設定し、Content-Languageヘッダーに反映します
+func SetLanguage(c *gin.Context, lang i18n.Language) {
+	c.Set(languageKey, lang)
+	c.Header("Content-Language", string(lang))
+}
+
+// GetLanguage はコンテキストからレスポンスの言語を取得します。設定されていない場合は既定の言語を返します
+func GetLanguage(c *gin.Context) i18n.Language {
+	value, exists := c.Get(languageKey)
+	if !exists {
+		return i18n.Default
+	}
+	lang, ok := value.(i18n.Language)
+	if !ok {
+		return i18n.Default
+	}
+
+	return lang
+}
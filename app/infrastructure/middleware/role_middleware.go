@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// RequireRole は指定されたいずれかのロールを持つユーザーのみを許可するミドルウェアです
+// JWTAuthMiddlewareの後に使用します
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, err := GetRole(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.Error(domainerr.Forbidden(i18n.PermissionDenied))
+		c.Abort()
+	}
+}
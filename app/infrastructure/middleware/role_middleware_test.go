@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve はsetupで認証済みのコンテキストを再現した上でミドルウェアを通してリクエストを処理し、レスポンスを返します
+// ミドルウェアが許可した場合は200を返します
+func serve(method string, setup gin.HandlerFunc, middleware gin.HandlerFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.Handle(method, "/", setup, middleware, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, "/", nil))
+	return w
+}
+
+func withRole(role model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("role", role)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  gin.HandlerFunc
+		roles  []model.Role
+		status int
+	}{
+		{"管理者は管理者のみのエンドポイントを使える", withRole(model.RoleAdmin), []model.Role{model.RoleAdmin}, http.StatusOK},
+		{"一般ユーザーは管理者のみのエンドポイントを使えない", withRole(model.RoleUser), []model.Role{model.RoleAdmin}, http.StatusForbidden},
+		{"いずれかのロールを持っていれば許可する", withRole(model.RoleUser), []model.Role{model.RoleAdmin, model.RoleUser}, http.StatusOK},
+		{"認証されていない", func(c *gin.Context) {}, []model.Role{model.RoleAdmin}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(http.MethodGet, tt.setup, RequireRole(tt.roles...))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// RequireScope は個人用アクセストークンやOAuthのアクセストークンで認証されたリクエストに指定されたスコープを要求するミドルウェアです
+// ログインで発行されたアクセストークンはユーザーの全ての権限を持つため、そのまま許可します
+// JWTAuthMiddlewareの後に使用します
+func RequireScope(scope model.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes, ok := GetScopes(c); ok && !model.ContainsScope(scopes, scope) {
+			c.Error(domainerr.Forbidden(i18n.InsufficientScope, scope))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScopeByMethod はGETとHEADのリクエストにreadを、それ以外のリクエストにwriteのスコープを要求するミドルウェアです
+func RequireScopeByMethod(read, write model.Scope) gin.HandlerFunc {
+	requireRead := RequireScope(read)
+	requireWrite := RequireScope(write)
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead:
+			requireRead(c)
+		default:
+			requireWrite(c)
+		}
+	}
+}
+
+// RequireSession はスコープで制限されたトークンでの操作を拒否し、ログインで発行されたアクセストークンのみを許可するミドルウェアです
+// パスワードの変更やトークンの管理など、アカウント自体を操作するエンドポイントに使用します
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetScopes(c); ok {
+			c.Error(domainerr.Forbidden(i18n.SessionRequired))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+)
+
+// withScopes は個人用アクセストークンやOAuthのアクセストークンで認証されたリクエストを再現します
+func withScopes(scopes ...model.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("scopes", scopes)
+	}
+}
+
+// withSession はログインで発行されたアクセストークンで認証されたリクエストを再現します
+func withSession(c *gin.Context) {}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  gin.HandlerFunc
+		status int
+	}{
+		{"ログインのトークンはスコープによらず許可する", withSession, http.StatusOK},
+		{"スコープを持つトークンは許可する", withScopes(model.ScopeTodosRead, model.ScopeUsersAdmin), http.StatusOK},
+		{"スコープを持たないトークンは拒否する", withScopes(model.ScopeTodosRead), http.StatusForbidden},
+		{"スコープが空のトークンは拒否する", withScopes(), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(http.MethodGet, tt.setup, RequireScope(model.ScopeUsersAdmin))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
+
+func TestRequireScopeByMethod(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		scopes []model.Scope
+		status int
+	}{
+		{"GETにはreadが必要", http.MethodGet, []model.Scope{model.ScopeTodosRead}, http.StatusOK},
+		{"HEADにはreadが必要", http.MethodHead, []model.Scope{model.ScopeTodosRead}, http.StatusOK},
+		{"writeのみではGETできない", http.MethodGet, []model.Scope{model.ScopeTodosWrite}, http.StatusForbidden},
+		{"POSTにはwriteが必要", http.MethodPost, []model.Scope{model.ScopeTodosWrite}, http.StatusOK},
+		{"readのみではPOSTできない", http.MethodPost, []model.Scope{model.ScopeTodosRead}, http.StatusForbidden},
+		{"readのみではDELETEできない", http.MethodDelete, []model.Scope{model.ScopeTodosRead}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.method, withScopes(tt.scopes...), RequireScopeByMethod(model.ScopeTodosRead, model.ScopeTodosWrite))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  gin.HandlerFunc
+		status int
+	}{
+		{"ログインのトークンは許可する", withSession, http.StatusOK},
+		{"スコープで制限されたトークンは拒否する", withScopes(model.ScopeTodosRead, model.ScopeTodosWrite, model.ScopeUsersAdmin), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(http.MethodPost, tt.setup, RequireSession())
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
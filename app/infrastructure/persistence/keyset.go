@@ -0,0 +1,185 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// keysetKey はキーセットページングの並び替えキーです
+// castはカーソルの値をSQLで比較する際の型、valueは行からカーソルの値を取り出す関数です
+type keysetKey[T any] struct {
+	expr  string
+	cast  string
+	desc  bool
+	value func(item T) string
+}
+
+// keysetSort は並び順を構成するキーの一覧です
+// 行を一意に並べるため、最後のキーは主キーである必要があります
+type keysetSort[T any] []keysetKey[T]
+
+// keysetCursor はカーソルの内容です
+// 並び順の名前を含め、異なる並び順のカーソルが使われた場合に検出します
+type keysetCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// reversed は全てのキーの昇順・降順を反転した並び順を返します
+func (s keysetSort[T]) reversed() keysetSort[T] {
+	result := make(keysetSort[T], len(s))
+	for i, key := range s {
+		key.desc = !key.desc
+		result[i] = key
+	}
+
+	return result
+}
+
+// orderBy はORDER BY句を返します
+func (s keysetSort[T]) orderBy() string {
+	clauses := make([]string, len(s))
+	for i, key := range s {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		clauses[i] = key.expr + " " + direction
+	}
+
+	return strings.Join(clauses, ", ")
+}
+
+// after はカーソルの値より後ろの行を取得する条件とその引数を返します
+// (k1 > v1) OR (k1 = v1 AND ((k2 > v2) OR (k2 = v2 AND ...))) の形の条件を組み立てます
+func (s keysetSort[T]) after(values []string) (string, []interface{}) {
+	key := s[0]
+	op := ">"
+	if key.desc {
+		op = "<"
+	}
+	placeholder := "?::" + key.cast
+	if len(s) == 1 {
+		return key.expr + " " + op + " " + placeholder, []interface{}{values[0]}
+	}
+	rest, restArgs := s[1:].after(values[1:])
+	condition := "(" + key.expr + " " + op + " " + placeholder + " OR (" +
+		key.expr + " = " + placeholder + " AND (" + rest + ")))"
+	args := append([]interface{}{values[0], values[0]}, restArgs...)
+
+	return condition, args
+}
+
+// values は行からカーソルの値を取り出します
+func (s keysetSort[T]) values(item T) []string {
+	values := make([]string, len(s))
+	for i, key := range s {
+		values[i] = key.value(item)
+	}
+
+	return values
+}
+
+// resolveSort は並び順の名前から並び順を取得します
+// 先頭に"-"が付いている場合は降順にします。名前が空の場合はdefaultSortを使います
+func resolveSort[T any](sorts map[string]keysetSort[T], name string, defaultSort string) (keysetSort[T], string, bool) {
+	if name == "" {
+		name = defaultSort
+	}
+	if sort, ok := sorts[name]; ok {
+		return sort, name, true
+	}
+	if sort, ok := sorts[strings.TrimPrefix(name, "-")]; ok && strings.HasPrefix(name, "-") {
+		return sort.reversed(), name, true
+	}
+
+	return nil, "", false
+}
+
+// paginate はキーセットページングを適用して1ページ分の行と次のページのカーソルを取得します
+func paginate[T any](
+	query *gorm.DB,
+	sort keysetSort[T],
+	sortName string,
+	cursor string,
+	limit int,
+) ([]T, string, error) {
+	if cursor != "" {
+		values, err := decodeCursor(cursor, sortName, len(sort))
+		if err != nil {
+			return nil, "", err
+		}
+		condition, args := sort.after(values)
+		query = query.Where(condition, args...)
+	}
+	var items []T
+	result := query.Order(sort.orderBy()).Limit(limit + 1).Find(&items)
+	if result.Error != nil {
+		return nil, "", result.Error
+	}
+	if len(items) <= limit {
+		return items, "", nil
+	}
+	items = items[:limit]
+
+	return items, encodeCursor(sortName, sort.values(items[limit-1])), nil
+}
+
+// encodeCursor はカーソルを不透明な文字列に変換します
+func encodeCursor(sortName string, values []string) string {
+	data, _ := json.Marshal(keysetCursor{Sort: sortName, Values: values})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor はカーソル文字列を検証して値を取り出します
+func decodeCursor(cursor string, sortName string, keys int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, repository.ErrInvalidCursor
+	}
+	var decoded keysetCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, repository.ErrInvalidCursor
+	}
+	if decoded.Sort != sortName || len(decoded.Values) != keys {
+		return nil, repository.ErrInvalidCursor
+	}
+
+	return decoded.Values, nil
+}
+
+// applyTimeRange は日時の範囲条件をクエリに適用します
+func applyTimeRange(query *gorm.DB, column string, timeRange repository.TimeRange) *gorm.DB {
+	if timeRange.From != nil {
+		query = query.Where(column+" >= ?", *timeRange.From)
+	}
+	if timeRange.To != nil {
+		query = query.Where(column+" < ?", *timeRange.To)
+	}
+
+	return query
+}
+
+// likePattern は部分一致検索用のLIKEパターンを返します
+func likePattern(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	return "%" + replacer.Replace(text) + "%"
+}
+
+// formatID はIDをカーソルの値に変換します
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// formatTime は日時をカーソルの値に変換します
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+)
+
+type keysetItem struct {
+	id        uint
+	createdAt time.Time
+}
+
+var testSorts = map[string]keysetSort[keysetItem]{
+	"created_at": {
+		{expr: "created_at", cast: "timestamptz", value: func(item keysetItem) string { return formatTime(item.createdAt) }},
+		{expr: "id", cast: "bigint", value: func(item keysetItem) string { return formatID(item.id) }},
+	},
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	item := keysetItem{id: 42, createdAt: time.Date(2024, 5, 1, 9, 30, 0, 123456000, time.FixedZone("JST", 9*60*60))}
+	values := testSorts["created_at"].values(item)
+	if want := []string{"2024-05-01T00:30:00.123456Z", "42"}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("values() = %v, want %v", values, want)
+	}
+
+	cursor := encodeCursor("-created_at", values)
+	got, err := decodeCursor(cursor, "-created_at", 2)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Errorf("decodeCursor() = %v, want %v", got, values)
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	valid := encodeCursor("created_at", []string{"2024-05-01T00:30:00Z", "42"})
+	tests := []struct {
+		name     string
+		cursor   string
+		sortName string
+		keys     int
+	}{
+		{"Base64URLでない", "!!!", "created_at", 2},
+		{"JSONでない", "bm90IGpzb24", "created_at", 2},
+		{"別の並び順のカーソル", valid, "-created_at", 2},
+		{"値の数が異なる", valid, "created_at", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor, tt.sortName, tt.keys); !errors.Is(err, repository.ErrInvalidCursor) {
+				t.Errorf("decodeCursor() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestResolveSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sortName string
+		wantName string
+		wantDesc bool
+		wantOK   bool
+	}{
+		{"空の場合は既定の並び順", "", "created_at", false, true},
+		{"昇順", "created_at", "created_at", false, true},
+		{"-を付けると降順", "-created_at", "-created_at", true, true},
+		{"存在しない並び順", "title", "", false, false},
+		{"-のみ", "-", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, name, ok := resolveSort(testSorts, tt.sortName, "created_at")
+			if ok != tt.wantOK || name != tt.wantName {
+				t.Fatalf("resolveSort(%q) = (%q, %v), want (%q, %v)", tt.sortName, name, ok, tt.wantName, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			for _, key := range sort {
+				if key.desc != tt.wantDesc {
+					t.Errorf("key %s desc = %v, want %v", key.expr, key.desc, tt.wantDesc)
+				}
+			}
+		})
+	}
+}
+
+func TestKeysetSortAfter(t *testing.T) {
+	values := []string{"2024-05-01T00:30:00Z", "42"}
+	tests := []struct {
+		name          string
+		sort          keysetSort[keysetItem]
+		wantCondition string
+		wantOrderBy   string
+	}{
+		{
+			name:          "昇順",
+			sort:          testSorts["created_at"],
+			wantCondition: "(created_at > ?::timestamptz OR (created_at = ?::timestamptz AND (id > ?::bigint)))",
+			wantOrderBy:   "created_at ASC, id ASC",
+		},
+		{
+			name:          "降順",
+			sort:          testSorts["created_at"].reversed(),
+			wantCondition: "(created_at < ?::timestamptz OR (created_at = ?::timestamptz AND (id < ?::bigint)))",
+			wantOrderBy:   "created_at DESC, id DESC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, args := tt.sort.after(values)
+			if condition != tt.wantCondition {
+				t.Errorf("after() condition = %q, want %q", condition, tt.wantCondition)
+			}
+			if want := []interface{}{values[0], values[0], values[1]}; !reflect.DeepEqual(args, want) {
+				t.Errorf("after() args = %v, want %v", args, want)
+			}
+			if got := tt.sort.orderBy(); got != tt.wantOrderBy {
+				t.Errorf("orderBy() = %q, want %q", got, tt.wantOrderBy)
+			}
+		})
+	}
+}
+
+func TestLikePattern(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"milk", "%milk%"},
+		{"100%", `%100\%%`},
+		{"a_b", `%a\_b%`},
+		{`C:\tmp`, `%C:\\tmp%`},
+	}
+	for _, tt := range tests {
+		if got := likePattern(tt.text); got != tt.want {
+			t.Errorf("likePattern(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
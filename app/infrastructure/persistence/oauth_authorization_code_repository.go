@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// OAuthAuthorizationCodeRepository はOAuthAuthorizationCodeRepositoryインターフェースの実装
+type OAuthAuthorizationCodeRepository struct {
+	DB *gorm.DB
+}
+
+// NewOAuthAuthorizationCodeRepository は新しいOAuthAuthorizationCodeRepositoryのインスタンスを作成します
+func NewOAuthAuthorizationCodeRepository(db *gorm.DB) repository.OAuthAuthorizationCodeRepository {
+	return &OAuthAuthorizationCodeRepository{
+		DB: db,
+	}
+}
+
+// FindByCodeHash は指定されたハッシュ値の認可コードを検索します
+func (r *OAuthAuthorizationCodeRepository) FindByCodeHash(codeHash string) (*model.OAuthAuthorizationCode, error) {
+	var code model.OAuthAuthorizationCode
+	result := r.DB.Where("code_hash = ?", codeHash).First(&code)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &code, nil
+}
+
+// Create は新しい認可コードを保存します
+func (r *OAuthAuthorizationCodeRepository) Create(code *model.OAuthAuthorizationCode) error {
+	result := r.DB.Create(code)
+
+	return result.Error
+}
+
+// MarkUsed は未使用の認可コードを使用済みにし、交換したセッションのFamilyIDを記録します
+// 同時に使用された場合でも1回だけ成功するよう、未使用の場合のみ更新し、更新できたかどうかを返します
+func (r *OAuthAuthorizationCodeRepository) MarkUsed(id uint, familyID string, usedAt time.Time) (bool, error) {
+	result := r.DB.Model(&model.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Updates(map[string]interface{}{"used_at": usedAt, "family_id": familyID})
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// PurgeExpiredBefore は指定された日時より前に有効期限が切れた認可コードを削除し、削除件数を返します
+func (r *OAuthAuthorizationCodeRepository) PurgeExpiredBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("expires_at < ?", before).Delete(&model.OAuthAuthorizationCode{})
+
+	return result.RowsAffected, result.Error
+}
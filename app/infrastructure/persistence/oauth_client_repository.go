@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// OAuthClientRepository はOAuthClientRepositoryインターフェースの実装
+type OAuthClientRepository struct {
+	DB *gorm.DB
+}
+
+// NewOAuthClientRepository は新しいOAuthClientRepositoryのインスタンスを作成します
+func NewOAuthClientRepository(db *gorm.DB) repository.OAuthClientRepository {
+	return &OAuthClientRepository{
+		DB: db,
+	}
+}
+
+// FindAll は全てのOAuthクライアントを登録順に取得します
+func (r *OAuthClientRepository) FindAll() ([]*model.OAuthClient, error) {
+	var clients []*model.OAuthClient
+	result := r.DB.Order("id").Find(&clients)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return clients, nil
+}
+
+// FindByID は指定されたIDのOAuthクライアントを検索します
+func (r *OAuthClientRepository) FindByID(id uint) (*model.OAuthClient, error) {
+	var client model.OAuthClient
+	result := r.DB.First(&client, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &client, nil
+}
+
+// FindByClientID は指定されたクライアントIDのOAuthクライアントを検索します
+func (r *OAuthClientRepository) FindByClientID(clientID string) (*model.OAuthClient, error) {
+	var client model.OAuthClient
+	result := r.DB.Where("client_id = ?", clientID).First(&client)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &client, nil
+}
+
+// Create は新しいOAuthクライアントを保存します
+func (r *OAuthClientRepository) Create(client *model.OAuthClient) error {
+	result := r.DB.Create(client)
+
+	return result.Error
+}
+
+// Delete はOAuthクライアントを削除します
+// クライアントの認可コードとセッションも外部キーの制約により削除されます
+func (r *OAuthClientRepository) Delete(id uint) error {
+	result := r.DB.Delete(&model.OAuthClient{}, id)
+
+	return result.Error
+}
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository はPersonalAccessTokenRepositoryインターフェースの実装
+type PersonalAccessTokenRepository struct {
+	DB *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository は新しいPersonalAccessTokenRepositoryのインスタンスを作成します
+func NewPersonalAccessTokenRepository(db *gorm.DB) repository.PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		DB: db,
+	}
+}
+
+// FindByID は指定されたIDの個人用アクセストークンを検索します
+func (r *PersonalAccessTokenRepository) FindByID(id uint) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	result := r.DB.First(&token, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// FindByTokenHash は指定されたハッシュ値の個人用アクセストークンを検索します
+func (r *PersonalAccessTokenRepository) FindByTokenHash(tokenHash string) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	result := r.DB.Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// FindByUserID は指定されたユーザーの個人用アクセストークンを作成日時の新しい順に取得します
+func (r *PersonalAccessTokenRepository) FindByUserID(userID uint) ([]*model.PersonalAccessToken, error) {
+	var tokens []*model.PersonalAccessToken
+	result := r.DB.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+// Create は新しい個人用アクセストークンを保存します
+func (r *PersonalAccessTokenRepository) Create(token *model.PersonalAccessToken) error {
+	result := r.DB.Create(token)
+
+	return result.Error
+}
+
+// Touch は個人用アクセストークンの最終利用日時を更新します
+func (r *PersonalAccessTokenRepository) Touch(id uint, usedAt time.Time) error {
+	result := r.DB.Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt).
+		Update("last_used_at", usedAt)
+
+	return result.Error
+}
+
+// Delete は個人用アクセストークンを削除します
+func (r *PersonalAccessTokenRepository) Delete(id uint) error {
+	result := r.DB.Delete(&model.PersonalAccessToken{}, id)
+
+	return result.Error
+}
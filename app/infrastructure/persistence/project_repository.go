@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ProjectRepository はProjectRepositoryインターフェースの実装
+type ProjectRepository struct {
+	DB *gorm.DB
+}
+
+// NewProjectRepository は新しいProjectRepositoryのインスタンスを作成します
+func NewProjectRepository(db *gorm.DB) repository.ProjectRepository {
+	return &ProjectRepository{
+		DB: db,
+	}
+}
+
+// FindByID は指定されたIDのプロジェクトを検索します
+func (r *ProjectRepository) FindByID(id uint) (*model.Project, error) {
+	var project model.Project
+	result := r.DB.First(&project, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &project, nil
+}
+
+// FindByUserID は指定されたユーザーのプロジェクトを名前順に取得します
+func (r *ProjectRepository) FindByUserID(userID uint, includeArchived bool) ([]*model.Project, error) {
+	var projects []*model.Project
+	query := r.DB.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	result := query.Order("name ASC").Find(&projects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return projects, nil
+}
+
+// FindByUserIDAndName は指定されたユーザーのプロジェクトを名前で検索します
+func (r *ProjectRepository) FindByUserIDAndName(userID uint, name string) (*model.Project, error) {
+	var project model.Project
+	result := r.DB.Where("user_id = ? AND name = ?", userID, name).First(&project)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &project, nil
+}
+
+// CountTodosByUserID は指定されたユーザーのプロジェクトごとの未完了・完了済みTodoの件数を集計します
+func (r *ProjectRepository) CountTodosByUserID(userID uint) ([]*model.ProjectTodoCount, error) {
+	var counts []*model.ProjectTodoCount
+	result := r.DB.Model(&model.Todo{}).
+		Select(
+			"project_id, "+
+				"COUNT(*) FILTER (WHERE NOT completed) AS open_count, "+
+				"COUNT(*) FILTER (WHERE completed) AS completed_count",
+		).
+		Where("user_id = ? AND project_id IS NOT NULL AND delete_flag = ?", userID, false).
+		Group("project_id").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return counts, nil
+}
+
+// Create は新しいプロジェクトを作成します
+func (r *ProjectRepository) Create(project *model.Project) error {
+	result := r.DB.Create(project)
+
+	return result.Error
+}
+
+// Update は既存のプロジェクトを更新します
+func (r *ProjectRepository) Update(project *model.Project) error {
+	result := r.DB.Save(project)
+
+	return result.Error
+}
+
+// Delete は指定されたIDのプロジェクトを削除します
+// 所属していたTodoは外部キー制約によりプロジェクトに属さない状態になります
+func (r *ProjectRepository) Delete(id uint) error {
+	result := r.DB.Delete(&model.Project{}, id)
+
+	return result.Error
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository はRefreshTokenRepositoryインターフェースの実装
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+// NewRefreshTokenRepository は新しいRefreshTokenRepositoryのインスタンスを作成します
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		DB: db,
+	}
+}
+
+// FindByTokenHash は指定されたハッシュ値のリフレッシュトークンを検索します
+func (r *RefreshTokenRepository) FindByTokenHash(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	result := r.DB.Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// Create は新しいリフレッシュトークンを保存します
+func (r *RefreshTokenRepository) Create(token *model.RefreshToken) error {
+	result := r.DB.Create(token)
+
+	return result.Error
+}
+
+// MarkUsed は未使用のリフレッシュトークンを使用済みにします
+// 同時に使用された場合でも1回だけ成功するよう、未使用の場合のみ更新し、更新できたかどうかを返します
+func (r *RefreshTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	result := r.DB.Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", usedAt)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// RevokeFamily は指定されたファミリーの全てのリフレッシュトークンを失効させます
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	result := r.DB.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now())
+
+	return result.Error
+}
+
+// RevokeByUserIDBefore は指定された日時より前にユーザーへ発行された全てのリフレッシュトークンを失効させます
+func (r *RefreshTokenRepository) RevokeByUserIDBefore(userID uint, before time.Time) error {
+	result := r.DB.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND created_at < ? AND revoked_at IS NULL", userID, before).
+		Update("revoked_at", time.Now())
+
+	return result.Error
+}
+
+// PurgeExpiredBefore は指定された日時より前に有効期限が切れたリフレッシュトークンを削除し、削除件数を返します
+func (r *RefreshTokenRepository) PurgeExpiredBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("expires_at < ?", before).Delete(&model.RefreshToken{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// revokedTokenSyncInterval はデータベースから失効済みトークンを読み込み直す間隔です
+// 他のサーバーで失効させたトークンは最大でこの時間だけ有効なままになります
+const revokedTokenSyncInterval = 30 * time.Second
+
+// RevokedTokenRepository はRevokedTokenRepositoryインターフェースの実装
+// 有効期限内の失効済みトークンをメモリに保持し、リクエストごとにデータベースを参照しないようにします
+type RevokedTokenRepository struct {
+	DB *gorm.DB
+
+	mu       sync.RWMutex
+	revoked  map[string]time.Time
+	syncedAt time.Time
+}
+
+// NewRevokedTokenRepository は新しいRevokedTokenRepositoryのインスタンスを作成します
+func NewRevokedTokenRepository(db *gorm.DB) repository.RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		DB:      db,
+		revoked: make(map[string]time.Time),
+	}
+}
+
+// Revoke はトークンを失効させます。既に失効している場合は何もしません
+func (r *RevokedTokenRepository) Revoke(token *model.RevokedToken) error {
+	result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(token)
+	if result.Error != nil {
+		return result.Error
+	}
+	r.mu.Lock()
+	r.revoked[token.JTI] = token.ExpiresAt
+	r.mu.Unlock()
+
+	return nil
+}
+
+// IsRevoked は指定されたjtiのトークンが失効しているかどうかを返します
+// 前回の読み込みから一定時間が経過している場合は、先にデータベースから読み込み直します
+func (r *RevokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	r.mu.RLock()
+	stale := time.Since(r.syncedAt) >= revokedTokenSyncInterval
+	_, revoked := r.revoked[jti]
+	r.mu.RUnlock()
+	if !stale || revoked {
+		return revoked, nil
+	}
+	if err := r.sync(); err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	_, revoked = r.revoked[jti]
+	r.mu.RUnlock()
+
+	return revoked, nil
+}
+
+// PurgeExpiredBefore は指定された日時より前に有効期限が切れた失効済みトークンを削除し、削除件数を返します
+func (r *RevokedTokenRepository) PurgeExpiredBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("expires_at < ?", before).Delete(&model.RevokedToken{})
+
+	return result.RowsAffected, result.Error
+}
+
+// sync は前回の読み込み以降に失効したトークンをデータベースから読み込み、有効期限が切れたものをメモリから取り除きます
+// 書き込みの遅延に備え、前回の読み込み時刻より少し前から読み込みます
+func (r *RevokedTokenRepository) sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.syncedAt) < revokedTokenSyncInterval {
+		return nil
+	}
+	now := time.Now()
+	query := r.DB.Where("expires_at > ?", now)
+	if !r.syncedAt.IsZero() {
+		query = query.Where("revoked_at >= ?", r.syncedAt.Add(-revokedTokenSyncInterval))
+	}
+	var tokens []*model.RevokedToken
+	if err := query.Find(&tokens).Error; err != nil {
+		return err
+	}
+	for jti, expiresAt := range r.revoked {
+		if !expiresAt.After(now) {
+			delete(r.revoked, jti)
+		}
+	}
+	for _, token := range tokens {
+		r.revoked[token.JTI] = token.ExpiresAt
+	}
+	r.syncedAt = now
+
+	return nil
+}
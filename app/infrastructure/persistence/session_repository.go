@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// SessionRepository はSessionRepositoryインターフェースの実装
+type SessionRepository struct {
+	DB *gorm.DB
+}
+
+// NewSessionRepository は新しいSessionRepositoryのインスタンスを作成します
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &SessionRepository{
+		DB: db,
+	}
+}
+
+// FindByID は指定されたIDのセッションを検索します
+func (r *SessionRepository) FindByID(id uint) (*model.Session, error) {
+	var session model.Session
+	result := r.DB.First(&session, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &session, nil
+}
+
+// FindByFamilyID は指定されたリフレッシュトークンのファミリーに対応するセッションを検索します
+func (r *SessionRepository) FindByFamilyID(familyID string) (*model.Session, error) {
+	var session model.Session
+	result := r.DB.Where("family_id = ?", familyID).First(&session)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &session, nil
+}
+
+// FindActiveByUserID は指定されたユーザーの終了していないセッションのうち、指定された日時より後に使われたものを最終利用日時の新しい順に取得します
+func (r *SessionRepository) FindActiveByUserID(userID uint, seenAfter time.Time) ([]*model.Session, error) {
+	var sessions []*model.Session
+	result := r.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, seenAfter).
+		Order("last_seen_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+// Create は新しいセッションを保存します
+func (r *SessionRepository) Create(session *model.Session) error {
+	result := r.DB.Create(session)
+
+	return result.Error
+}
+
+// Touch はセッションの最終利用日時を更新します
+func (r *SessionRepository) Touch(id uint, seenAt time.Time) error {
+	result := r.DB.Model(&model.Session{}).
+		Where("id = ? AND last_seen_at < ?", id, seenAt).
+		Update("last_seen_at", seenAt)
+
+	return result.Error
+}
+
+// Revoke は指定されたセッションを終了します
+func (r *SessionRepository) Revoke(id uint) error {
+	result := r.DB.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+
+	return result.Error
+}
+
+// RevokeByUserIDBefore は指定された日時より前に作成されたユーザーの全てのセッションを終了します
+func (r *SessionRepository) RevokeByUserIDBefore(userID uint, before time.Time) error {
+	result := r.DB.Model(&model.Session{}).
+		Where("user_id = ? AND created_at < ? AND revoked_at IS NULL", userID, before).
+		Update("revoked_at", time.Now())
+
+	return result.Error
+}
+
+// PurgeInactiveBefore は指定された日時より前に終了した、または最後に使われたセッションを削除し、削除件数を返します
+func (r *SessionRepository) PurgeInactiveBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("revoked_at < ? OR last_seen_at < ?", before, before).Delete(&model.Session{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository はTagRepositoryインターフェースの実装
+type TagRepository struct {
+	DB *gorm.DB
+}
+
+// NewTagRepository は新しいTagRepositoryのインスタンスを作成します
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &TagRepository{
+		DB: db,
+	}
+}
+
+// FindByID は指定されたIDのタグを検索します
+func (r *TagRepository) FindByID(id uint) (*model.Tag, error) {
+	var tag model.Tag
+	result := r.DB.First(&tag, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &tag, nil
+}
+
+// FindByUserID は指定されたユーザーのタグを名前順に取得します
+func (r *TagRepository) FindByUserID(userID uint) ([]*model.Tag, error) {
+	var tags []*model.Tag
+	result := r.DB.Where("user_id = ?", userID).Order("name ASC").Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tags, nil
+}
+
+// FindByUserIDAndName は指定されたユーザーのタグを名前で検索します
+func (r *TagRepository) FindByUserIDAndName(userID uint, name string) (*model.Tag, error) {
+	var tag model.Tag
+	result := r.DB.Where("user_id = ? AND name = ?", userID, name).First(&tag)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &tag, nil
+}
+
+// Create は新しいタグを作成します
+func (r *TagRepository) Create(tag *model.Tag) error {
+	result := r.DB.Create(tag)
+
+	return result.Error
+}
+
+// Update は既存のタグを更新します
+func (r *TagRepository) Update(tag *model.Tag) error {
+	result := r.DB.Save(tag)
+
+	return result.Error
+}
+
+// Delete は指定されたIDのタグを削除します
+// Todoとの関連付けは外部キー制約により削除されます
+func (r *TagRepository) Delete(id uint) error {
+	result := r.DB.Delete(&model.Tag{}, id)
+
+	return result.Error
+}
+
+// AttachToTodo はTodoにタグを関連付けます
+// 既に関連付けられている場合は何もしません
+func (r *TagRepository) AttachToTodo(todoID uint, tagID uint) error {
+	result := r.DB.Table("todo_tags").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"todo_id": todoID, "tag_id": tagID})
+
+	return result.Error
+}
+
+// DetachFromTodo はTodoからタグの関連付けを解除します
+func (r *TagRepository) DetachFromTodo(todoID uint, tagID uint) error {
+	result := r.DB.Exec("DELETE FROM todo_tags WHERE todo_id = ? AND tag_id = ?", todoID, tagID)
+
+	return result.Error
+}
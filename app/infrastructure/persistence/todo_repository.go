@@ -1,9 +1,14 @@
 package persistence
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TodoRepository はTodoRepositoryインターフェースの実装
@@ -21,7 +26,7 @@ func NewTodoRepository(db *gorm.DB) repository.TodoRepository {
 // FindByID は指定されたIDのTodoを検索します
 func (r *TodoRepository) FindByID(id uint) (*model.Todo, error) {
 	var todo model.Todo
-	result := r.DB.First(&todo, id)
+	result := r.active().First(&todo, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -35,7 +40,7 @@ func (r *TodoRepository) FindByID(id uint) (*model.Todo, error) {
 // FindAll はすべてのTodoを取得します
 func (r *TodoRepository) FindAll() ([]*model.Todo, error) {
 	var todos []*model.Todo
-	result := r.DB.Find(&todos)
+	result := r.active().Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -46,7 +51,7 @@ func (r *TodoRepository) FindAll() ([]*model.Todo, error) {
 // FindByUserID は指定されたユーザーIDに関連するTodoを検索します
 func (r *TodoRepository) FindByUserID(userID uint) ([]*model.Todo, error) {
 	var todos []*model.Todo
-	result := r.DB.Where("user_id = ?", userID).Find(&todos)
+	result := r.active().Where("user_id = ?", userID).Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -54,23 +59,353 @@ func (r *TodoRepository) FindByUserID(userID uint) ([]*model.Todo, error) {
 	return todos, nil
 }
 
+// FindByParentID は指定されたTodoのサブタスクを作成順に検索します
+func (r *TodoRepository) FindByParentID(parentID uint) ([]*model.Todo, error) {
+	var todos []*model.Todo
+	result := r.active().Where("parent_id = ?", parentID).Order("id ASC").Find(&todos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return todos, nil
+}
+
+// FindPage は絞り込み条件に一致するTodoをキーセットページングで取得します
+func (r *TodoRepository) FindPage(filter repository.TodoFilter, query repository.ListQuery) (*repository.TodoPage, error) {
+	sort, sortName, ok := resolveSort(todoSorts, query.Sort, "id")
+	if !ok {
+		return nil, repository.ErrInvalidSort
+	}
+	var total int64
+	if err := r.filter(r.DB.Model(&model.Todo{}), filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	todos, nextCursor, err := paginate(
+		r.filter(r.DB.Preload("Tags"), filter),
+		sort,
+		sortName,
+		query.Cursor,
+		query.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.TodoPage{Todos: todos, NextCursor: nextCursor, Total: total}, nil
+}
+
+// Search は指定されたユーザーのゴミ箱にないTodoをタイトルと説明で全文検索し、関連度の高い順に取得します
+// 検索語はwebsearch_to_tsqueryの構文（"語句"、or、-除外）で解釈します
+// 日本語などの単語の区切りがない文字を含む場合は、空白で区切った語の部分一致で検索します
+// スニペットはHTMLエスケープした上で一致した語を<mark>タグで囲みます
+func (r *TodoRepository) Search(userID uint, text string, limit int) ([]*model.TodoSearchResult, error) {
+	if containsCJK(text) {
+		return r.searchSubstrings(userID, parseSearchTerms(text), limit)
+	}
+	var hits []todoSearchHit
+	result := r.DB.Raw(`
+		SELECT
+			todos.id,
+			ts_rank(todos.search_vector, query) AS rank,
+			ts_headline('simple', todos.title, query, ?) AS title_snippet,
+			ts_headline('simple', todos.description, query, ?) AS description_snippet
+		FROM todos, websearch_to_tsquery('simple', ?) AS query
+		WHERE todos.user_id = ? AND todos.delete_flag = false AND todos.search_vector @@ query
+		ORDER BY rank DESC, todos.id DESC
+		LIMIT ?`,
+		searchHeadlineOptions, searchHeadlineOptions, text, userID, limit,
+	).Scan(&hits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	results, err := r.searchResults(hits)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.TitleSnippet = markHeadline(result.TitleSnippet)
+		result.DescriptionSnippet = markHeadline(result.DescriptionSnippet)
+	}
+
+	return results, nil
+}
+
+// todoSearchHit は検索に一致したTodoのIDと関連度、スニペットです
+type todoSearchHit struct {
+	ID                 uint
+	Rank               float64
+	TitleSnippet       string
+	DescriptionSnippet string
+}
+
+// searchSubstrings は全ての検索語をタイトルまたは説明に含み、除外する語を含まないTodoを検索します
+// 関連度はタイトルに含まれる語を説明に含まれる語より高く評価します
+func (r *TodoRepository) searchSubstrings(userID uint, terms searchTerms, limit int) ([]*model.TodoSearchResult, error) {
+	if len(terms.include) == 0 {
+		return []*model.TodoSearchResult{}, nil
+	}
+	query := r.DB.Model(&model.Todo{}).Where("todos.user_id = ? AND todos.delete_flag = ?", userID, false)
+	var rankExprs []string
+	var rankArgs []any
+	for _, term := range terms.include {
+		pattern := likePattern(term)
+		query = query.Where("(todos.title ILIKE ? OR todos.description ILIKE ?)", pattern, pattern)
+		rankExprs = append(rankExprs,
+			"CASE WHEN todos.title ILIKE ? THEN 1.0 ELSE 0 END",
+			"CASE WHEN todos.description ILIKE ? THEN 0.4 ELSE 0 END",
+		)
+		rankArgs = append(rankArgs, pattern, pattern)
+	}
+	for _, term := range terms.exclude {
+		pattern := likePattern(term)
+		query = query.Where("NOT (todos.title ILIKE ? OR todos.description ILIKE ?)", pattern, pattern)
+	}
+	var hits []todoSearchHit
+	result := query.
+		Select("todos.id, ("+strings.Join(rankExprs, " + ")+") AS rank", rankArgs...).
+		Order("rank DESC, todos.id DESC").
+		Limit(limit).
+		Scan(&hits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	results, err := r.searchResults(hits)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.TitleSnippet = markSubstrings(result.Todo.Title, terms.include, 0)
+		result.DescriptionSnippet = markSubstrings(result.Todo.Description, terms.include, substringSnippetRunes)
+	}
+
+	return results, nil
+}
+
+// searchResults は検索に一致したTodoを取得し、一致した順に並べた検索結果を返します
+func (r *TodoRepository) searchResults(hits []todoSearchHit) ([]*model.TodoSearchResult, error) {
+	if len(hits) == 0 {
+		return []*model.TodoSearchResult{}, nil
+	}
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	var todos []*model.Todo
+	if err := r.active().Where("todos.id IN ?", ids).Find(&todos).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Todo, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+	results := make([]*model.TodoSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		todo, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &model.TodoSearchResult{
+			Todo:               todo,
+			Rank:               hit.Rank,
+			TitleSnippet:       hit.TitleSnippet,
+			DescriptionSnippet: hit.DescriptionSnippet,
+		})
+	}
+
+	return results, nil
+}
+
 // Create は新しいTodoを作成します
+// タグの関連付けはTagRepositoryで管理するため保存しません
 func (r *TodoRepository) Create(todo *model.Todo) error {
-	result := r.DB.Create(todo)
+	result := r.DB.Omit(clause.Associations).Create(todo)
 
 	return result.Error
 }
 
 // Update は既存のTodoを更新します
 func (r *TodoRepository) Update(todo *model.Todo) error {
-	result := r.DB.Save(todo)
+	result := r.DB.Omit(clause.Associations).Save(todo)
 
 	return result.Error
 }
 
-// Delete は指定されたIDのTodoを削除します
+// FindTrashedByID は指定されたIDのゴミ箱にあるTodoを検索します
+func (r *TodoRepository) FindTrashedByID(id uint) (*model.Todo, error) {
+	var todo model.Todo
+	result := r.trashed().First(&todo, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &todo, nil
+}
+
+// FindTrashedByUserID は指定されたユーザーのゴミ箱にあるTodoを削除日時の新しい順に検索します
+func (r *TodoRepository) FindTrashedByUserID(userID uint) ([]*model.Todo, error) {
+	var todos []*model.Todo
+	result := r.trashed().Where("user_id = ?", userID).Order("deleted_at DESC").Find(&todos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return todos, nil
+}
+
+// Delete は指定されたIDのTodoとそのサブタスクをゴミ箱に移動します
 func (r *TodoRepository) Delete(id uint) error {
+	result := r.DB.Exec(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM todos WHERE id = ?
+			UNION ALL
+			SELECT todos.id FROM todos JOIN tree ON todos.parent_id = tree.id
+		)
+		UPDATE todos
+		SET delete_flag = true, deleted_at = ?, updated_at = ?
+		WHERE id IN (SELECT id FROM tree) AND delete_flag = false`,
+		id, time.Now(), time.Now(),
+	)
+
+	return result.Error
+}
+
+// Restore は指定されたIDのTodoとそのサブタスクをゴミ箱から復元します
+func (r *TodoRepository) Restore(id uint) error {
+	result := r.DB.Exec(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM todos WHERE id = ?
+			UNION ALL
+			SELECT todos.id FROM todos JOIN tree ON todos.parent_id = tree.id
+		)
+		UPDATE todos
+		SET delete_flag = false, deleted_at = NULL, updated_at = ?
+		WHERE id IN (SELECT id FROM tree) AND delete_flag = true`,
+		id, time.Now(),
+	)
+
+	return result.Error
+}
+
+// HardDelete は指定されたIDのTodoを完全に削除します
+// サブタスクとタグの関連付けは外部キー制約により削除されます
+func (r *TodoRepository) HardDelete(id uint) error {
 	result := r.DB.Delete(&model.Todo{}, id)
 
 	return result.Error
 }
+
+// PurgeTrashedBefore は指定された日時より前にゴミ箱に移動されたTodoを完全に削除し、削除件数を返します
+func (r *TodoRepository) PurgeTrashedBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("delete_flag = ? AND deleted_at < ?", true, before).Delete(&model.Todo{})
+
+	return result.RowsAffected, result.Error
+}
+
+// filter はゴミ箱にないTodoのうち絞り込み条件に一致するものを対象とするクエリを返します
+func (r *TodoRepository) filter(query *gorm.DB, filter repository.TodoFilter) *gorm.DB {
+	query = query.Where("todos.delete_flag = ?", false)
+	if filter.UserID != nil {
+		query = query.Where("todos.user_id = ?", *filter.UserID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("todos.project_id = ?", *filter.ProjectID)
+	}
+	if filter.Completed != nil {
+		query = query.Where("todos.completed = ?", *filter.Completed)
+	}
+	query = applyTimeRange(query, "todos.created_at", filter.Created)
+	query = applyTimeRange(query, "todos.updated_at", filter.Updated)
+	query = applyTimeRange(query, "todos.due_at", filter.Due)
+	if filter.Text != "" {
+		pattern := likePattern(filter.Text)
+		query = query.Where("(todos.title ILIKE ? OR todos.description ILIKE ?)", pattern, pattern)
+	}
+	if len(filter.Tags) > 0 {
+		tagged := "SELECT COUNT(DISTINCT tags.name) FROM todo_tags " +
+			"JOIN tags ON tags.id = todo_tags.tag_id " +
+			"WHERE todo_tags.todo_id = todos.id AND tags.name IN ?"
+		if filter.MatchAllTags {
+			query = query.Where("("+tagged+") = ?", filter.Tags, countDistinct(filter.Tags))
+		} else {
+			query = query.Where("("+tagged+") > 0", filter.Tags)
+		}
+	}
+
+	return query
+}
+
+// active はゴミ箱にないTodoを対象とするクエリを返します
+func (r *TodoRepository) active() *gorm.DB {
+	return r.DB.Preload("Tags").Where("todos.delete_flag = ?", false)
+}
+
+// trashed はゴミ箱にあるTodoを対象とするクエリを返します
+func (r *TodoRepository) trashed() *gorm.DB {
+	return r.DB.Preload("Tags").Where("todos.delete_flag = ?", true)
+}
+
+// todoDueAt は期限のない行を最後に並べるための期限の式です
+const todoDueAt = "COALESCE(todos.due_at, 'infinity'::timestamptz)"
+
+var todoIDKey = keysetKey[*model.Todo]{
+	expr:  "todos.id",
+	cast:  "bigint",
+	value: func(todo *model.Todo) string { return formatID(todo.ID) },
+}
+
+var todoDueAtKey = keysetKey[*model.Todo]{
+	expr: todoDueAt,
+	cast: "timestamptz",
+	value: func(todo *model.Todo) string {
+		if todo.DueAt == nil {
+			return "infinity"
+		}
+		return formatTime(*todo.DueAt)
+	},
+}
+
+// todoSorts はTodo一覧で指定できる並び順です。名前はrepository.TodoSortsと一致させます
+// priorityは優先度の高い順、期限の近い順に並べます
+var todoSorts = map[string]keysetSort[*model.Todo]{
+	"id": {todoIDKey},
+	"created_at": {
+		{
+			expr:  "todos.created_at",
+			cast:  "timestamptz",
+			value: func(todo *model.Todo) string { return formatTime(todo.CreatedAt) },
+		},
+		todoIDKey,
+	},
+	"updated_at": {
+		{
+			expr:  "todos.updated_at",
+			cast:  "timestamptz",
+			value: func(todo *model.Todo) string { return formatTime(todo.UpdatedAt) },
+		},
+		todoIDKey,
+	},
+	"due_at": {todoDueAtKey, todoIDKey},
+	"priority": {
+		{
+			expr:  "todos.priority",
+			cast:  "integer",
+			desc:  true,
+			value: func(todo *model.Todo) string { return strconv.Itoa(int(todo.Priority)) },
+		},
+		todoDueAtKey,
+		todoIDKey,
+	},
+}
+
+// countDistinct は重複を除いた要素数を返します
+func countDistinct(values []string) int {
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		seen[value] = true
+	}
+
+	return len(seen)
+}
@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// ts_headlineが一致箇所を囲む区切り文字です
+// スニペットをHTMLエスケープした後に<mark>タグへ置き換えるため、エスケープの影響を受けない私用領域の文字を使います
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+// searchHeadlineOptions は検索結果のスニペットの生成条件です
+const searchHeadlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"` +
+	", MaxWords=35, MinWords=15, MaxFragments=2"
+
+// substringSnippetRunes は部分一致検索のスニペットに含める一致箇所の前後の文字数です
+const substringSnippetRunes = 40
+
+// searchTerms は部分一致検索の検索語です
+type searchTerms struct {
+	include []string
+	exclude []string
+}
+
+// containsCJK は文字列に漢字・ひらがな・カタカナ・ハングルが含まれるかを返します
+// これらの文字は単語の区切りがなく'simple'設定のtsvectorでは語に分割できないため、部分一致で検索します
+func containsCJK(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSearchTerms は検索語を空白で区切り、先頭が-の語を除外する語として解釈します
+// 部分一致検索ではwebsearch_to_tsqueryのorは使えないため、全ての語を含むTodoを対象にします
+func parseSearchTerms(text string) searchTerms {
+	var terms searchTerms
+	for _, field := range strings.Fields(strings.ReplaceAll(text, `"`, " ")) {
+		if exclude, ok := strings.CutPrefix(field, "-"); ok {
+			if exclude != "" {
+				terms.exclude = append(terms.exclude, exclude)
+			}
+			continue
+		}
+		terms.include = append(terms.include, field)
+	}
+
+	return terms
+}
+
+// markHeadline はts_headlineのスニペットをHTMLエスケープし、一致箇所の区切り文字を<mark>タグに置き換えます
+// タイトルや説明に含まれるHTMLはそのまま表示されるよう全てエスケープします
+func markHeadline(snippet string) string {
+	escaped := html.EscapeString(snippet)
+
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(escaped)
+}
+
+// markSubstrings は文字列のうち検索語に一致する箇所（大文字小文字を区別しない）を<mark>タグで囲み、HTMLエスケープして返します
+// maxRunesが0より大きい場合は、最初の一致箇所とその前後maxRunes文字まで（一致しない場合は先頭から2*maxRunes文字まで）の抜粋にします
+func markSubstrings(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+	folded := []rune(strings.ToLower(text))
+	if len(folded) != len(runes) {
+		// 小文字にすると文字数が変わる場合は位置を対応付けられないため、一致箇所を囲まずに返します
+		return html.EscapeString(text)
+	}
+	marked := make([]bool, len(runes))
+	first, firstEnd := -1, -1
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if string(folded[i:i+len(needle)]) != string(needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first, firstEnd = i, i+len(needle)
+			}
+		}
+	}
+	start, end := 0, len(runes)
+	switch {
+	case maxRunes > 0 && first >= 0:
+		start = max(first-maxRunes, 0)
+		end = min(firstEnd+maxRunes, len(runes))
+	case maxRunes > 0:
+		end = min(2*maxRunes, len(runes))
+	}
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			b.WriteString("<mark>")
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i == end-1 || !marked[i+1]) {
+			b.WriteString("</mark>")
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
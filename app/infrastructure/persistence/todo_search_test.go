@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContainsCJK(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"buy milk", false},
+		{"買い物", true},
+		{"カレー", true},
+		{"milk 牛乳", true},
+		{"장보기", true},
+		{"café", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := containsCJK(tt.text); got != tt.want {
+			t.Errorf("containsCJK(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseSearchTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want searchTerms
+	}{
+		{"買い物", searchTerms{include: []string{"買い物"}}},
+		{" 買い物  牛乳 ", searchTerms{include: []string{"買い物", "牛乳"}}},
+		{`"買い物" -牛乳`, searchTerms{include: []string{"買い物"}, exclude: []string{"牛乳"}}},
+		{"買い物 -", searchTerms{include: []string{"買い物"}}},
+	}
+	for _, tt := range tests {
+		if got := parseSearchTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSearchTerms(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMarkHeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{
+			name:    "一致箇所を<mark>で囲む",
+			snippet: "buy " + highlightStart + "milk" + highlightStop + " today",
+			want:    "buy <mark>milk</mark> today",
+		},
+		{
+			name:    "タイトルのHTMLはエスケープする",
+			snippet: `<img src=x onerror="alert(1)"> ` + highlightStart + "milk" + highlightStop,
+			want:    "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>milk</mark>",
+		},
+		{
+			name:    "<mark>タグを含むタイトルもエスケープする",
+			snippet: "<mark>" + highlightStart + "milk" + highlightStop + "</mark>",
+			want:    "&lt;mark&gt;<mark>milk</mark>&lt;/mark&gt;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markHeadline(tt.snippet); got != tt.want {
+				t.Errorf("markHeadline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkSubstrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		maxRunes int
+		want     string
+	}{
+		{
+			name:  "日本語の部分一致を<mark>で囲む",
+			text:  "週末の買い物リスト",
+			terms: []string{"買い物"},
+			want:  "週末の<mark>買い物</mark>リスト",
+		},
+		{
+			name:  "大文字小文字を区別せず、複数の語を囲む",
+			text:  "Milkと牛乳",
+			terms: []string{"milk", "牛乳"},
+			want:  "<mark>Milk</mark>と<mark>牛乳</mark>",
+		},
+		{
+			name:  "隣接・重複する一致箇所はまとめて囲む",
+			text:  "買い物物",
+			terms: []string{"買い物", "物物"},
+			want:  "<mark>買い物物</mark>",
+		},
+		{
+			name:  "HTMLはエスケープする",
+			text:  "<script>買い物</script>",
+			terms: []string{"買い物"},
+			want:  "&lt;script&gt;<mark>買い物</mark>&lt;/script&gt;",
+		},
+		{
+			name:     "長い文字列は最初の一致箇所の前後を抜粋する",
+			text:     "あいうえおかきくけこ買い物さしすせそたちつてと",
+			terms:    []string{"買い物"},
+			maxRunes: 3,
+			want:     "…くけこ<mark>買い物</mark>さしす…",
+		},
+		{
+			name:     "一致しない長い文字列は先頭を抜粋する",
+			text:     "あいうえおかきくけこ",
+			terms:    []string{"買い物"},
+			maxRunes: 2,
+			want:     "あいうえ…",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markSubstrings(tt.text, tt.terms, tt.maxRunes); got != tt.want {
+				t.Errorf("markSubstrings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
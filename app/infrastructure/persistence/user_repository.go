@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/domain/repository"
 	"gorm.io/gorm"
@@ -21,7 +23,7 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 // FindByID はIDでユーザーを検索します
 func (r *UserRepository) FindByID(id uint) (*model.User, error) {
 	var user model.User
-	result := r.DB.First(&user, id)
+	result := r.active().First(&user, id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -35,7 +37,7 @@ func (r *UserRepository) FindByID(id uint) (*model.User, error) {
 // FindByUsername はユーザー名でユーザーを検索します
 func (r *UserRepository) FindByUsername(username string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("username = ?", username).First(&user)
+	result := r.active().Where("username = ?", username).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -49,7 +51,7 @@ func (r *UserRepository) FindByUsername(username string) (*model.User, error) {
 // FindByEmail はメールアドレスでユーザーを検索します
 func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("email = ?", email).First(&user)
+	result := r.active().Where("email = ?", email).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -63,7 +65,7 @@ func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
 // FindByUsernameAndPassword はユーザー名とパスワードでユーザーを検索します
 func (r *UserRepository) FindByUsernameAndPassword(username, password string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("username = ? AND password = ?", username, password).First(&user)
+	result := r.active().Where("username = ? AND password = ?", username, password).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -77,7 +79,7 @@ func (r *UserRepository) FindByUsernameAndPassword(username, password string) (*
 // FindByEmailAndPassword はメールアドレスとパスワードでユーザーを検索します
 func (r *UserRepository) FindByEmailAndPassword(email, password string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("email = ? AND password = ?", email, password).First(&user)
+	result := r.active().Where("email = ? AND password = ?", email, password).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -91,7 +93,7 @@ func (r *UserRepository) FindByEmailAndPassword(email, password string) (*model.
 // FindByUsernameOrEmail はユーザー名またはメールアドレスでユーザーを検索します
 func (r *UserRepository) FindByUsernameOrEmail(username, email string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("username = ? OR email = ?", username, email).First(&user)
+	result := r.active().Where("username = ? OR email = ?", username, email).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -105,7 +107,7 @@ func (r *UserRepository) FindByUsernameOrEmail(username, email string) (*model.U
 // FindByUsernameAndEmail はユーザー名とメールアドレスでユーザーを検索します
 func (r *UserRepository) FindByUsernameAndEmail(username, email string) (*model.User, error) {
 	var user model.User
-	result := r.DB.Where("username = ? AND email = ?", username, email).First(&user)
+	result := r.active().Where("username = ? AND email = ?", username, email).First(&user)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -119,7 +121,7 @@ func (r *UserRepository) FindByUsernameAndEmail(username, email string) (*model.
 // FindAll は全てのユーザーを取得します
 func (r *UserRepository) FindAll() ([]*model.User, error) {
 	var users []*model.User
-	result := r.DB.Find(&users)
+	result := r.active().Find(&users)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -147,9 +149,146 @@ func (r *UserRepository) Update(user *model.User) (*model.User, error) {
 	return user, nil
 }
 
-// Remove はユーザーを削除します
-func (r *UserRepository) Remove(id uint) error {
+// FindPage は絞り込み条件に一致する有効なユーザーをキーセットページングで取得します
+func (r *UserRepository) FindPage(filter repository.UserFilter, query repository.ListQuery) (*repository.UserPage, error) {
+	sort, sortName, ok := resolveSort(userSorts, query.Sort, "id")
+	if !ok {
+		return nil, repository.ErrInvalidSort
+	}
+	var total int64
+	if err := r.filter(r.DB.Model(&model.User{}), filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	users, nextCursor, err := paginate(r.filter(r.DB, filter), sort, sortName, query.Cursor, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UserPage{Users: users, NextCursor: nextCursor, Total: total}, nil
+}
+
+// FindDeactivatedByID はIDで無効化されたユーザーを検索します
+func (r *UserRepository) FindDeactivatedByID(id uint) (*model.User, error) {
+	var user model.User
+	result := r.DB.Where("delete_flag = ?", true).First(&user, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// FindByUsernameOrEmailWithDeactivated は無効化されたユーザーも含めてユーザー名またはメールアドレスで検索します
+// 無効化されたユーザーのユーザー名とメールアドレスも再利用できないため、重複確認に使用します
+func (r *UserRepository) FindByUsernameOrEmailWithDeactivated(username, email string) (*model.User, error) {
+	var user model.User
+	result := r.DB.Where("username = ? OR email = ?", username, email).First(&user)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// Deactivate はユーザーを無効化します
+// 発行済みのトークンは失効させないため、AuthUseCase.LogoutEverywhereと合わせて使用します
+func (r *UserRepository) Deactivate(id uint) error {
+	now := time.Now()
+	result := r.DB.Model(&model.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"delete_flag": true, "deactivated_at": now, "updated_at": now})
+
+	return result.Error
+}
+
+// RevokeTokensBefore は指定された日時より前にユーザーへ発行されたトークンを一括で失効させます
+func (r *UserRepository) RevokeTokensBefore(id uint, before time.Time) error {
+	result := r.DB.Model(&model.User{}).
+		Where("id = ?", id).
+		Update("tokens_revoked_at", before)
+
+	return result.Error
+}
+
+// Reactivate は無効化されたユーザーを再び有効にします
+func (r *UserRepository) Reactivate(id uint) error {
+	result := r.DB.Model(&model.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"delete_flag": false, "deactivated_at": nil, "updated_at": time.Now()})
+
+	return result.Error
+}
+
+// Purge はユーザーを完全に削除します
+// ユーザーのTodo・タグ・プロジェクトは外部キー制約により削除されます
+func (r *UserRepository) Purge(id uint) error {
 	result := r.DB.Delete(&model.User{}, id)
 
 	return result.Error
 }
+
+// PurgeDeactivatedBefore は指定された日時より前に無効化されたユーザーを完全に削除し、削除件数を返します
+func (r *UserRepository) PurgeDeactivatedBefore(before time.Time) (int64, error) {
+	result := r.DB.Where("delete_flag = ? AND deactivated_at < ?", true, before).Delete(&model.User{})
+
+	return result.RowsAffected, result.Error
+}
+
+// filter は有効なユーザーのうち絞り込み条件に一致するものを対象とするクエリを返します
+func (r *UserRepository) filter(query *gorm.DB, filter repository.UserFilter) *gorm.DB {
+	query = query.Where("users.delete_flag = ?", false)
+	query = applyTimeRange(query, "users.created_at", filter.Created)
+	query = applyTimeRange(query, "users.updated_at", filter.Updated)
+	if filter.Text != "" {
+		pattern := likePattern(filter.Text)
+		query = query.Where("(users.username ILIKE ? OR users.email ILIKE ?)", pattern, pattern)
+	}
+
+	return query
+}
+
+// active は無効化されていないユーザーを対象とするクエリを返します
+func (r *UserRepository) active() *gorm.DB {
+	return r.DB.Where("users.delete_flag = ?", false)
+}
+
+var userIDKey = keysetKey[*model.User]{
+	expr:  "users.id",
+	cast:  "bigint",
+	value: func(user *model.User) string { return formatID(user.ID) },
+}
+
+// userSorts はユーザー一覧で指定できる並び順です
+var userSorts = map[string]keysetSort[*model.User]{
+	"id": {userIDKey},
+	"username": {
+		{
+			expr:  "users.username",
+			cast:  "text",
+			value: func(user *model.User) string { return user.Username },
+		},
+		userIDKey,
+	},
+	"created_at": {
+		{
+			expr:  "users.created_at",
+			cast:  "timestamptz",
+			value: func(user *model.User) string { return formatTime(user.CreatedAt) },
+		},
+		userIDKey,
+	},
+	"updated_at": {
+		{
+			expr:  "users.updated_at",
+			cast:  "timestamptz",
+			value: func(user *model.User) string { return formatTime(user.UpdatedAt) },
+		},
+		userIDKey,
+	},
+}
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserSettingsRepository はUserSettingsRepositoryインターフェースの実装
+type UserSettingsRepository struct {
+	DB *gorm.DB
+}
+
+// NewUserSettingsRepository は新しいUserSettingsRepositoryのインスタンスを作成します
+func NewUserSettingsRepository(db *gorm.DB) repository.UserSettingsRepository {
+	return &UserSettingsRepository{
+		DB: db,
+	}
+}
+
+// FindByUserID は指定されたユーザーの設定を検索します。まだ保存されていない場合はnilを返します
+func (r *UserSettingsRepository) FindByUserID(userID uint) (*model.UserSettings, error) {
+	var settings model.UserSettings
+	result := r.DB.Where("user_id = ?", userID).First(&settings)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &settings, nil
+}
+
+// Save はユーザーの設定を作成または更新します
+func (r *UserSettingsRepository) Save(settings *model.UserSettings) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"timezone",
+			"locale",
+			"default_sort",
+			"week_start",
+			"default_project_id",
+			"updated_at",
+		}),
+	}).Create(settings).Error
+}
@@ -2,9 +2,14 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
 	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
 // AuthHandler は認証関連のHTTPリクエストを処理します
@@ -25,27 +30,164 @@ func (h *AuthHandler) Signin(c *gin.Context) {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
 		return
 	}
-	token, err := h.authUseCase.Signin(input.Username, input.Password)
+	client := usecase.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+	tokens, err := h.authUseCase.Signin(input.Username, input.Password, client)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.SetCookie(
-		"token", // key
-		token,   // value
-		86400,   // expire
-		"/",     // path
-		"",      // domain
-		false,   // secure
-		true,    // HTTPOnly
-	)
+	setTokenCookies(c, tokens)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       localize(c, i18n.SigninSucceeded),
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    int(tokens.ExpiresIn.Seconds()),
+	})
+}
+
+// Refresh はリフレッシュトークンを使ってアクセストークンを再発行します
+// リフレッシュトークンはリクエストボディのrefresh_tokenまたはCookieから受け取り、使用後は新しいものに置き換えます
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &input); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	if input.RefreshToken == "" {
+		input.RefreshToken, _ = c.Cookie(refreshTokenCookie)
+	}
+	if input.RefreshToken == "" {
+		c.Error(domainerr.InvalidField("refresh_token", i18n.RefreshTokenRequired))
+		return
+	}
+	tokens, err := h.authUseCase.Refresh(input.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	setTokenCookies(c, tokens)
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "ログイン成功",
-		"token":   token,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    int(tokens.ExpiresIn.Seconds()),
 	})
 }
+
+// Logout は現在のアクセストークンを失効させ、そのセッションを終了してログアウトします
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.authUseCase.Logout(claims); err != nil {
+		c.Error(err)
+		return
+	}
+	clearTokenCookies(c)
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.LoggedOut)})
+}
+
+// LogoutEverywhere は全ての端末からログアウトします
+// beforeを指定した場合は、その日時より前に発行されたトークンのみを失効させます
+func (h *AuthHandler) LogoutEverywhere(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Before *time.Time `json:"before"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &input); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	if err := h.authUseCase.LogoutEverywhere(userID, input.Before); err != nil {
+		c.Error(err)
+		return
+	}
+	clearTokenCookies(c)
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.LoggedOutEverywhere)})
+}
+
+// ChangePassword は現在のパスワードを確認した上でログインユーザーのパスワードを変更します
+// 変更後は全ての端末からログアウトされるため、再度ログインが必要です
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.authUseCase.ChangePassword(userID, input.CurrentPassword, input.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+	clearTokenCookies(c)
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.PasswordChanged)})
+}
+
+// JWKS はアクセストークンの検証に使用できる公開鍵をJWKS形式で返すエンドポイント
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+
+	c.JSON(http.StatusOK, utility.PublicJWKS())
+}
+
+// refreshTokenCookie はリフレッシュトークンを保存するCookieの名前です
+const refreshTokenCookie = "refresh_token"
+
+// setTokenCookies はアクセストークンとリフレッシュトークンをCookieに設定します
+// リフレッシュトークンは再発行のエンドポイントにのみ送信されるようパスを限定します
+func setTokenCookies(c *gin.Context, tokens *usecase.TokenPair) {
+	c.SetCookie(
+		"token",                         // key
+		tokens.AccessToken,              // value
+		int(tokens.ExpiresIn.Seconds()), // expire
+		"/",                             // path
+		"",                              // domain
+		false,                           // secure
+		true,                            // HTTPOnly
+	)
+	c.SetCookie(
+		refreshTokenCookie,                       // key
+		tokens.RefreshToken,                      // value
+		int(utility.RefreshTokenTTL().Seconds()), // expire
+		"/api/v1/token",                          // path
+		"",                                       // domain
+		false,                                    // secure
+		true,                                     // HTTPOnly
+	)
+}
+
+// clearTokenCookies はアクセストークンとリフレッシュトークンのCookieを削除します
+func clearTokenCookies(c *gin.Context) {
+	c.SetCookie("token", "", -1, "/", "", false, true)
+	c.SetCookie(refreshTokenCookie, "", -1, "/api/v1/token", "", false, true)
+}
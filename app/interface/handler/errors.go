@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+func init() {
+	// 入力値のエラーで構造体のフィールド名ではなくJSONの項目名を返すようにします
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// jsonFieldName は構造体のフィールドに対応するJSONの項目名を返します
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
+// bindJSON はリクエストボディをinputにバインドします
+// 失敗した場合は項目ごとの詳細を含む入力値のエラーを返します
+func bindJSON(c *gin.Context, input any) error {
+	if err := c.ShouldBindJSON(input); err != nil {
+		return bindingError(err)
+	}
+
+	return nil
+}
+
+// bindingError はバインドの失敗を入力値のエラーに変換します
+func bindingError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]domainerr.FieldError, len(validationErrors))
+		for i, fieldErr := range validationErrors {
+			fields[i] = domainerr.FieldError{
+				Field:   fieldErr.Field(),
+				Message: validationMessage(fieldErr),
+				Args:    []any{fieldErr.Field()},
+			}
+		}
+		return domainerr.Validation(i18n.ProblemValidation, fields...)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return domainerr.InvalidField(typeErr.Field, i18n.FieldType, typeErr.Field)
+	}
+
+	return domainerr.Validation(i18n.InvalidRequestBody).Wrap(err)
+}
+
+// validationMessage は検証ルールごとのメッセージIDを返します。メッセージには項目名を埋め込みます
+func validationMessage(fieldErr validator.FieldError) i18n.MessageID {
+	switch fieldErr.Tag() {
+	case "required":
+		return i18n.FieldRequired
+	case "email":
+		return i18n.FieldEmail
+	default:
+		return i18n.FieldInvalid
+	}
+}
+
+// parseID はパスパラメータからIDを取得します
+func parseID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, domainerr.InvalidField(name, i18n.InvalidID)
+	}
+
+	return uint(id), nil
+}
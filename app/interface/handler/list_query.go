@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+const (
+	// defaultPageLimit は一覧取得時の既定の件数です
+	defaultPageLimit = 50
+	// defaultSearchLimit は全文検索時の既定の件数です
+	defaultSearchLimit = 20
+	// maxPageLimit は一覧取得時に指定できる最大の件数です
+	maxPageLimit = 100
+)
+
+// parseListQuery はクエリパラメータ limit, cursor, sort からページングの条件を取得します
+func parseListQuery(c *gin.Context) (repository.ListQuery, error) {
+	limit, err := parseLimit(c, defaultPageLimit)
+	if err != nil {
+		return repository.ListQuery{}, err
+	}
+
+	return repository.ListQuery{
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}, nil
+}
+
+// parseLimit はクエリパラメータ limit から取得件数を取得します。省略時はdefaultLimitを返します
+func parseLimit(c *gin.Context, defaultLimit int) (int, error) {
+	if c.Query("limit") == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 || limit > maxPageLimit {
+		return 0, domainerr.InvalidField("limit", i18n.InvalidLimit, maxPageLimit)
+	}
+
+	return limit, nil
+}
+
+// parseTimeRange はクエリパラメータ <name>_from, <name>_to (RFC3339) から日時の範囲条件を取得します
+func parseTimeRange(c *gin.Context, name string) (repository.TimeRange, error) {
+	var timeRange repository.TimeRange
+	for _, bound := range []struct {
+		key  string
+		dest **time.Time
+	}{
+		{name + "_from", &timeRange.From},
+		{name + "_to", &timeRange.To},
+	} {
+		value := c.Query(bound.key)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return timeRange, domainerr.InvalidField(bound.key, i18n.InvalidTimestamp, bound.key)
+		}
+		*bound.dest = &t
+	}
+
+	return timeRange, nil
+}
+
+// parseCompleted はクエリパラメータ completed=true|false から完了状態の条件を取得します
+func parseCompleted(c *gin.Context) (*bool, error) {
+	if c.Query("completed") == "" {
+		return nil, nil
+	}
+	completed, err := strconv.ParseBool(c.Query("completed"))
+	if err != nil {
+		return nil, domainerr.InvalidField("completed", i18n.InvalidCompleted)
+	}
+
+	return &completed, nil
+}
+
+// setPageHeaders はページングの情報をレスポンスヘッダーに設定します
+// 総件数をX-Total-Countに、次のページがある場合はカーソルをX-Next-Cursorに、そのURLをLinkに設定します
+func setPageHeaders(c *gin.Context, total int64, nextCursor string) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	if nextCursor == "" {
+		return
+	}
+	c.Header("X-Next-Cursor", nextCursor)
+	next := url.URL{Path: c.Request.URL.Path}
+	params := c.Request.URL.Query()
+	params.Set("cursor", nextCursor)
+	next.RawQuery = params.Encode()
+	c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+}
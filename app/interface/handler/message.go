@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// localize はメッセージをリクエストの言語に翻訳します
+func localize(c *gin.Context, id i18n.MessageID, args ...any) string {
+	return i18n.Translate(middleware.GetLanguage(c), id, args...)
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// OAuthHandler はOAuth2の認可サーバー関連のHTTPリクエストを処理します
+type OAuthHandler struct {
+	oauthUseCase *usecase.OAuthUseCase
+}
+
+// NewOAuthHandler は新しいOAuthHandlerのインスタンスを作成します
+func NewOAuthHandler(oauthUseCase *usecase.OAuthUseCase) *OAuthHandler {
+	return &OAuthHandler{
+		oauthUseCase: oauthUseCase,
+	}
+}
+
+// GetClients は登録されているOAuthクライアント一覧を取得するエンドポイント（管理者用）
+func (h *OAuthHandler) GetClients(c *gin.Context) {
+	clients, err := h.oauthUseCase.GetClients()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToOAuthClientResponseList(clients))
+}
+
+// RegisterClient は新しいOAuthクライアントを登録するエンドポイント（管理者用）
+// 機密クライアントのクライアントシークレットはこのレスポンスでのみ返します
+func (h *OAuthHandler) RegisterClient(c *gin.Context) {
+	var input struct {
+		Name         string   `json:"name" binding:"required"`
+		RedirectURIs []string `json:"redirect_uris" binding:"required"`
+		Scopes       []string `json:"scopes" binding:"required"`
+		Confidential bool     `json:"confidential"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	client, clientSecret, err := h.oauthUseCase.RegisterClient(usecase.RegisterOAuthClientInput{
+		Name:         input.Name,
+		RedirectURIs: input.RedirectURIs,
+		Scopes:       input.Scopes,
+		Confidential: input.Confidential,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.RegisteredOAuthClientResponse{
+		OAuthClientResponse: dto.ToOAuthClientResponse(client),
+		ClientSecret:        clientSecret,
+	})
+}
+
+// DeleteClient はOAuthクライアントを削除するエンドポイント（管理者用）
+// クライアントに認可したセッションも全て終了します
+func (h *OAuthHandler) DeleteClient(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.oauthUseCase.DeleteClient(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.OAuthClientDeleted)})
+}
+
+// GetAuthorization は認可リクエストを検証し、ユーザーに同意を求める内容を返すエンドポイント
+// パラメータはクエリ文字列で受け取ります
+func (h *OAuthHandler) GetAuthorization(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	consent, err := h.oauthUseCase.PrepareAuthorization(authorizationRequestFromQuery(c), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &dto.OAuthConsentResponse{
+		ClientID:    consent.Client.ClientID,
+		ClientName:  consent.Client.Name,
+		RedirectURI: consent.RedirectURI,
+		Scopes:      consent.Scopes,
+		State:       consent.State,
+	})
+}
+
+// Authorize はユーザーの同意の結果を受け取り、クライアントに返すリダイレクト先を返すエンドポイント
+// approveがtrueの場合は認可コードを、falseの場合はaccess_deniedエラーをリダイレクト先に含めます
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		ResponseType        string `json:"response_type"`
+		ClientID            string `json:"client_id"`
+		RedirectURI         string `json:"redirect_uri"`
+		Scope               string `json:"scope"`
+		State               string `json:"state"`
+		CodeChallenge       string `json:"code_challenge"`
+		CodeChallengeMethod string `json:"code_challenge_method"`
+		Approve             bool   `json:"approve"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	redirectTo, err := h.oauthUseCase.Authorize(usecase.AuthorizationRequest{
+		ResponseType:        input.ResponseType,
+		ClientID:            input.ClientID,
+		RedirectURI:         input.RedirectURI,
+		Scope:               input.Scope,
+		State:               input.State,
+		CodeChallenge:       input.CodeChallenge,
+		CodeChallengeMethod: input.CodeChallengeMethod,
+	}, userID, input.Approve)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"redirect_to": redirectTo})
+}
+
+// Token はOAuthクライアントに認可コードまたはリフレッシュトークンと引き換えにトークンを発行するエンドポイント
+// RFC 6749に従い、パラメータはapplication/x-www-form-urlencodedで、クライアントの認証情報はBasic認証かパラメータで受け取ります
+func (h *OAuthHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	req := usecase.TokenRequest{
+		GrantType:    c.PostForm("grant_type"),
+		Code:         c.PostForm("code"),
+		RedirectURI:  c.PostForm("redirect_uri"),
+		ClientID:     c.PostForm("client_id"),
+		ClientSecret: c.PostForm("client_secret"),
+		CodeVerifier: c.PostForm("code_verifier"),
+		RefreshToken: c.PostForm("refresh_token"),
+	}
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		// Basic認証のクライアントIDとシークレットはURLエンコードされています（RFC 6749 2.3.1）
+		req.ClientID, _ = url.QueryUnescape(clientID)
+		req.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+	client := usecase.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+	tokens, err := h.oauthUseCase.Token(req, client)
+	if err != nil {
+		var oauthErr *usecase.OAuthError
+		if !errors.As(err, &oauthErr) {
+			c.Error(err)
+			return
+		}
+		status := http.StatusBadRequest
+		if oauthErr.Code == usecase.OAuthErrorInvalidClient {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{
+			"error":             oauthErr.Code,
+			"error_description": oauthErr.Localize(middleware.GetLanguage(c)),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  tokens.AccessToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(tokens.ExpiresIn.Seconds()),
+		"refresh_token": tokens.RefreshToken,
+		"scope":         tokens.Scope,
+	})
+}
+
+// authorizationRequestFromQuery はクエリ文字列から認可リクエストのパラメータを取り出します
+func authorizationRequestFromQuery(c *gin.Context) usecase.AuthorizationRequest {
+	return usecase.AuthorizationRequest{
+		ResponseType:        c.Query("response_type"),
+		ClientID:            c.Query("client_id"),
+		RedirectURI:         c.Query("redirect_uri"),
+		Scope:               c.Query("scope"),
+		State:               c.Query("state"),
+		CodeChallenge:       c.Query("code_challenge"),
+		CodeChallengeMethod: c.Query("code_challenge_method"),
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// PersonalAccessTokenHandler は個人用アクセストークン関連のHTTPリクエストを処理します
+type PersonalAccessTokenHandler struct {
+	accessTokenUseCase *usecase.PersonalAccessTokenUseCase
+}
+
+// NewPersonalAccessTokenHandler は新しいPersonalAccessTokenHandlerのインスタンスを作成します
+func NewPersonalAccessTokenHandler(accessTokenUseCase *usecase.PersonalAccessTokenUseCase) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		accessTokenUseCase: accessTokenUseCase,
+	}
+}
+
+// GetTokens は現在ログイン中のユーザーの個人用アクセストークン一覧を取得するエンドポイント
+func (h *PersonalAccessTokenHandler) GetTokens(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	tokens, err := h.accessTokenUseCase.GetTokens(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPersonalAccessTokenResponseList(tokens))
+}
+
+// CreateToken は新しい個人用アクセストークンを作成するエンドポイント
+// トークン自体はこのレスポンスでのみ返します
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Name      string     `json:"name" binding:"required"`
+		Scopes    []string   `json:"scopes" binding:"required"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	token, plainToken, err := h.accessTokenUseCase.CreateToken(userID, usecase.CreatePersonalAccessTokenInput{
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &dto.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: dto.ToPersonalAccessTokenResponse(token),
+		Token:                       plainToken,
+	})
+}
+
+// DeleteToken は個人用アクセストークンを削除するエンドポイント
+func (h *PersonalAccessTokenHandler) DeleteToken(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.accessTokenUseCase.DeleteToken(id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.AccessTokenDeleted)})
+}
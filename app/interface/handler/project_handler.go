@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// ProjectHandler はプロジェクト関連のHTTPリクエストを処理します
+type ProjectHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+}
+
+// NewProjectHandler は新しいProjectHandlerのインスタンスを作成します
+func NewProjectHandler(projectUseCase *usecase.ProjectUseCase) *ProjectHandler {
+	return &ProjectHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// GetProjects は現在ログイン中のユーザーのプロジェクトを取得するエンドポイント
+// クエリパラメータ archived=true を指定するとアーカイブ済みのプロジェクトも含めます
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	includeArchived := c.Query("archived") == "true"
+	projects, err := h.projectUseCase.GetProjectsByUserID(userID, includeArchived)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProjectResponseList(projects))
+}
+
+// GetProjectByID は特定のプロジェクトを取得するエンドポイント
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	project, err := h.projectUseCase.GetProjectByID(id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProjectResponse(project))
+}
+
+// CreateProject は新しいプロジェクトを作成するエンドポイント
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	project, err := h.projectUseCase.CreateProject(input.Name, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToProjectResponse(project))
+}
+
+// UpdateProject はプロジェクトの名前を変更するエンドポイント
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	project, err := h.projectUseCase.RenameProject(id, input.Name, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProjectResponse(project))
+}
+
+// ArchiveProject はプロジェクトをアーカイブするエンドポイント
+func (h *ProjectHandler) ArchiveProject(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveProject はプロジェクトのアーカイブを解除するエンドポイント
+func (h *ProjectHandler) UnarchiveProject(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+// DeleteProject はプロジェクトを削除するエンドポイント
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.projectUseCase.DeleteProject(id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.ProjectDeleted)})
+}
+
+// setArchived はプロジェクトのアーカイブ状態を変更します
+func (h *ProjectHandler) setArchived(c *gin.Context, archived bool) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	project, err := h.projectUseCase.ArchiveProject(id, archived, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProjectResponse(project))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// SessionHandler はログイン中のセッション関連のHTTPリクエストを処理します
+type SessionHandler struct {
+	sessionUseCase *usecase.SessionUseCase
+}
+
+// NewSessionHandler は新しいSessionHandlerのインスタンスを作成します
+func NewSessionHandler(sessionUseCase *usecase.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// GetSessions は現在ログイン中のユーザーのセッション一覧を取得するエンドポイント
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	claims, err := middleware.GetClaims(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sessions, err := h.sessionUseCase.GetSessions(claims.UserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSessionResponseList(sessions, claims.SessionID))
+}
+
+// RevokeSession は指定されたセッションを終了させ、その端末をログアウトさせるエンドポイント
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.sessionUseCase.RevokeSession(id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.SessionRevoked)})
+}
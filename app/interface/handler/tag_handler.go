@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// TagHandler はタグ関連のHTTPリクエストを処理します
+type TagHandler struct {
+	tagUseCase *usecase.TagUseCase
+}
+
+// NewTagHandler は新しいTagHandlerのインスタンスを作成します
+func NewTagHandler(tagUseCase *usecase.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+	}
+}
+
+// GetTags は現在ログイン中のユーザーのタグを取得するエンドポイント
+func (h *TagHandler) GetTags(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	tags, err := h.tagUseCase.GetTagsByUserID(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponseList(tags))
+}
+
+// CreateTag は新しいタグを作成するエンドポイント
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	tag, err := h.tagUseCase.CreateTag(input.Name, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTagResponse(tag))
+}
+
+// UpdateTag はタグの名前を変更するエンドポイント
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	tag, err := h.tagUseCase.RenameTag(id, input.Name, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponse(tag))
+}
+
+// DeleteTag はタグを削除するエンドポイント
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.tagUseCase.DeleteTag(id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.TagDeleted)})
+}
+
+// AttachTag はTodoにタグを付けるエンドポイント
+func (h *TagHandler) AttachTag(c *gin.Context) {
+	h.changeTodoTag(c, h.tagUseCase.AttachTag)
+}
+
+// DetachTag はTodoからタグを外すエンドポイント
+func (h *TagHandler) DetachTag(c *gin.Context) {
+	h.changeTodoTag(c, h.tagUseCase.DetachTag)
+}
+
+// changeTodoTag はTodoとタグのIDをパスから取得し、関連付けを変更します
+func (h *TagHandler) changeTodoTag(
+	c *gin.Context,
+	change func(todoID uint, tagID uint, currentUserID uint) (*model.Todo, error),
+) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	todoID, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	tagID, err := parseID(c, "tagId")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	todo, err := change(todoID, tagID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTodoResponse(todo))
+}
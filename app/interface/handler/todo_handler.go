@@ -3,11 +3,15 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
 	"github.com/jugeeem/golang-todo.git/app/domain/dto"
 	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
 	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
 // TodoHandler はTodo関連のHTTPリクエストを処理します
@@ -22,50 +26,84 @@ func NewTodoHandler(todoUseCase *usecase.TodoUseCase) *TodoHandler {
 	}
 }
 
-// GetAllTodos は全てのTodoタスクを取得するエンドポイント
+// GetAllTodos は全てのTodoタスクをページ単位で取得するエンドポイント
+// 絞り込みと並び順の指定はGetTodosByUserと同じです
 func (h *TodoHandler) GetAllTodos(c *gin.Context) {
-	todos, err := h.todoUseCase.GetAllTodos()
+	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToTodoResponseList(todos))
+	input, err := parseTodoListInput(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, err := h.todoUseCase.GetAllTodos(input, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, dto.ToTodoResponseList(page.Todos))
 }
 
-// GetTodoByID は特定のTodoタスクを取得するエンドポイント
+// GetTodoByID は特定のTodoタスクをサブタスクと進捗率を含めて取得するエンドポイント
+// 自分のTodoのみ取得でき、管理者は全てのユーザーのTodoを取得できます
 func (h *TodoHandler) GetTodoByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	actor, err := currentActor(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
-	todo, err := h.todoUseCase.GetTodoByID(uint(id))
+	todo, err := h.todoUseCase.GetTodoByID(id, actor)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Todoが見つかりません"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, todo)
+	c.JSON(http.StatusOK, dto.ToTodoResponse(todo))
 }
 
 // CreateTodo は新しいTodoタスクを作成するエンドポイント
 func (h *TodoHandler) CreateTodo(c *gin.Context) {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証が必要です"})
+		c.Error(err)
 		return
 	}
 	var input struct {
-		Title       string `json:"title" binding:"required"`
-		Description string `json:"description"`
+		Title       string     `json:"title" binding:"required"`
+		Description string     `json:"description"`
+		Priority    string     `json:"priority"`
+		ProjectID   *uint      `json:"project_id"`
+		ParentID    *uint      `json:"parent_id"`
+		StartAt     *time.Time `json:"start_at"`
+		DueAt       *time.Time `json:"due_at"`
+		Recurrence  string     `json:"recurrence"`
 	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
 		return
 	}
-	todo, err := h.todoUseCase.CreateTodo(input.Title, input.Description, userID)
+	todo, err := h.todoUseCase.CreateTodo(usecase.CreateTodoInput{
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    input.Priority,
+		ProjectID:   input.ProjectID,
+		ParentID:    input.ParentID,
+		StartAt:     input.StartAt,
+		DueAt:       input.DueAt,
+		Recurrence:  input.Recurrence,
+	}, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -73,81 +111,263 @@ func (h *TodoHandler) CreateTodo(c *gin.Context) {
 }
 
 // UpdateTodo はTodoタスクを更新するエンドポイント
+// clear_start_at、clear_due_atにtrueを指定すると開始日時と期限を削除します
 func (h *TodoHandler) UpdateTodo(c *gin.Context) {
-	userID, err := middleware.GetUserID(c)
+	actor, err := currentActor(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証が必要です"})
+		c.Error(err)
 		return
 	}
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	id, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
 	var input struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Completed   *bool  `json:"completed"`
+		Title        string     `json:"title"`
+		Description  string     `json:"description"`
+		Completed    *bool      `json:"completed"`
+		Priority     *string    `json:"priority"`
+		StartAt      *time.Time `json:"start_at"`
+		DueAt        *time.Time `json:"due_at"`
+		ClearStartAt bool       `json:"clear_start_at"`
+		ClearDueAt   bool       `json:"clear_due_at"`
+		Recurrence   *string    `json:"recurrence"`
 	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
 		return
 	}
 	todo, err := h.todoUseCase.UpdateTodo(
-		uint(id),
-		input.Title,
-		input.Description,
-		input.Completed,
-		userID,
+		id,
+		usecase.UpdateTodoInput{
+			Title:        input.Title,
+			Description:  input.Description,
+			Completed:    input.Completed,
+			Priority:     input.Priority,
+			StartAt:      input.StartAt,
+			DueAt:        input.DueAt,
+			ClearStartAt: input.ClearStartAt,
+			ClearDueAt:   input.ClearDueAt,
+			Recurrence:   input.Recurrence,
+		},
+		actor,
 	)
 	if err != nil {
-		if err.Error() == "このTodoを編集する権限がありません" {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.ToTodoResponse(todo))
 }
 
-// GetTodosByUser は現在ログイン中のユーザーのTodoタスクを取得するエンドポイント
+// GetTodosByUser は現在ログイン中のユーザーのTodoタスクをページ単位で取得するエンドポイント
+// limit（既定50、最大100）とcursorでページングし、次のページのカーソルはX-Next-CursorとLinkヘッダーで返します
+// sort=id|created_at|updated_at|due_at|priority で並び替え、先頭に"-"を付けると降順になります
+// クエリパラメータ due=overdue|today|week または due_within=N で期限による絞り込みができます
+// 本日と今週の範囲、sortを省略した場合の並び順はユーザー設定に従います
+// completed=true|false、created_from/created_to、updated_from/updated_to（RFC3339）、q（タイトル・説明の部分一致）でも絞り込めます
+// tag=a&tag=b でタグによる絞り込みができ、tag_match=all で全てのタグが付いたTodoのみを返します
+// project_id=N を指定すると指定されたプロジェクトに属するTodoのみを返します
 func (h *TodoHandler) GetTodosByUser(c *gin.Context) {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証が必要です"})
+		c.Error(err)
+		return
+	}
+	input, err := parseTodoListInput(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	todos, err := h.todoUseCase.GetTodosByUserID(userID)
+	page, err := h.todoUseCase.GetTodosByUserID(userID, input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToTodoResponseList(todos))
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, dto.ToTodoResponseList(page.Todos))
+}
+
+// parseTodoListInput はクエリパラメータからTodo一覧の取得条件を組み立てます
+func parseTodoListInput(c *gin.Context) (usecase.TodoListInput, error) {
+	var input usecase.TodoListInput
+	query, err := parseListQuery(c)
+	if err != nil {
+		return input, err
+	}
+	input.Query = query
+	switch c.Query("due") {
+	case "", "overdue", "today", "week":
+		input.Due = c.Query("due")
+	default:
+		return input, domainerr.InvalidField("due", i18n.InvalidDue)
+	}
+	if c.Query("due_within") != "" {
+		days, err := strconv.Atoi(c.Query("due_within"))
+		if err != nil || days <= 0 {
+			return input, domainerr.InvalidField("due_within", i18n.InvalidDueWithin)
+		}
+		input.DueWithinDays = days
+	}
+	tagMatch := c.DefaultQuery("tag_match", "any")
+	if tagMatch != "any" && tagMatch != "all" {
+		return input, domainerr.InvalidField("tag_match", i18n.InvalidTagMatch)
+	}
+	if c.Query("project_id") != "" {
+		projectID, err := strconv.ParseUint(c.Query("project_id"), 10, 64)
+		if err != nil {
+			return input, domainerr.InvalidField("project_id", i18n.InvalidProjectID)
+		}
+		id := uint(projectID)
+		input.Filter.ProjectID = &id
+	}
+	if input.Filter.Completed, err = parseCompleted(c); err != nil {
+		return input, err
+	}
+	if input.Filter.Created, err = parseTimeRange(c, "created"); err != nil {
+		return input, err
+	}
+	if input.Filter.Updated, err = parseTimeRange(c, "updated"); err != nil {
+		return input, err
+	}
+	input.Filter.Text = c.Query("q")
+	input.Filter.Tags = c.QueryArray("tag")
+	input.Filter.MatchAllTags = tagMatch == "all"
+
+	return input, nil
+}
+
+// MoveTodo はTodoタスクを別のプロジェクトに移動するエンドポイント
+// project_idにnullを指定するとプロジェクトから外します
+func (h *TodoHandler) MoveTodo(c *gin.Context) {
+	actor, err := currentActor(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		ProjectID *uint `json:"project_id"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	todo, err := h.todoUseCase.MoveTodo(id, input.ProjectID, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTodoResponse(todo))
 }
 
-// DeleteTodo はTodoタスクを削除するエンドポイント
+// DeleteTodo はTodoタスクをゴミ箱に移動するエンドポイント
+// クエリパラメータ permanent=true を指定すると完全に削除します
 func (h *TodoHandler) DeleteTodo(c *gin.Context) {
+	actor, err := currentActor(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	permanent := c.Query("permanent") == "true"
+	if err := h.todoUseCase.DeleteTodo(id, actor, permanent); err != nil {
+		c.Error(err)
+		return
+	}
+	if permanent {
+		c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.TodoPurged)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": localize(c, i18n.TodoDeleted)})
+}
+
+// SearchTodos は現在ログイン中のユーザーのTodoタスクを全文検索するエンドポイント
+// q に検索語を指定し、関連度の高い順に limit 件（既定20、最大100）を一致箇所のスニペットと共に返します
+func (h *TodoHandler) SearchTodos(c *gin.Context) {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証が必要です"})
+		c.Error(err)
 		return
 	}
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		c.Error(domainerr.InvalidField("q", i18n.SearchQueryRequired))
+		return
+	}
+	limit, err := parseLimit(c, defaultSearchLimit)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
-	if err := h.todoUseCase.DeleteTodo(uint(id), userID); err != nil {
-		if err.Error() == "このTodoを削除する権限がありません" {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		}
+	results, err := h.todoUseCase.SearchTodos(userID, text, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTodoSearchResultResponseList(results))
+}
+
+// GetTrashedTodos は現在ログイン中のユーザーのゴミ箱にあるTodoタスクを取得するエンドポイント
+func (h *TodoHandler) GetTrashedTodos(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	todos, err := h.todoUseCase.GetTrashedTodos(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTodoResponseList(todos))
+}
+
+// RestoreTodo はゴミ箱にあるTodoタスクを復元するエンドポイント
+func (h *TodoHandler) RestoreTodo(c *gin.Context) {
+	actor, err := currentActor(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	todo, err := h.todoUseCase.RestoreTodo(id, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTodoResponse(todo))
+}
+
+// currentActor はコンテキストから現在のユーザーとそのロールを取得します
+func currentActor(c *gin.Context) (usecase.Actor, error) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return usecase.Actor{}, err
+	}
+	role, err := middleware.GetRole(c)
+	if err != nil {
+		return usecase.Actor{}, err
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Todoを削除しました"})
+	return usecase.Actor{UserID: userID, Role: role}, nil
 }
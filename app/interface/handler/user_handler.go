@@ -2,11 +2,14 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
 	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
 	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
 // UserHandler はユーザー関連のHTTPリクエストを処理する
@@ -29,17 +32,17 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		Password        string `json:"password" binding:"required"`
 		ConfirmPassword string `json:"confirmPassword" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
 		return
 	}
 	if input.Password != input.ConfirmPassword {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "パスワードが一致しません"})
+		c.Error(domainerr.InvalidField("confirmPassword", i18n.PasswordMismatch))
 		return
 	}
 	user, err := h.userUseCase.CreateUser(input.Username, input.Password, input.Email)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -48,67 +51,179 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // GetUserByID はIDでユーザーを取得する
 func (h *UserHandler) GetUserByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	id, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
-	user, err := h.userUseCase.GetUserByID(uint(id))
+	user, err := h.userUseCase.GetUserByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.ToUserResponse(user))
 }
 
-// GetAllUsers は全ユーザーを取得する
+// GetAllUsers は全ユーザーをページ単位で取得する
+// limit、cursor、sort=id|username|created_at|updated_at でページングと並び替えを行います
+// created_from/created_to、updated_from/updated_to（RFC3339）、q（ユーザー名・メールアドレスの部分一致）で絞り込めます
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.userUseCase.GetAllUsers()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToUserResponseList(users))
+	filter := repository.UserFilter{Text: c.Query("q")}
+	if filter.Created, err = parseTimeRange(c, "created"); err != nil {
+		c.Error(err)
+		return
+	}
+	if filter.Updated, err = parseTimeRange(c, "updated"); err != nil {
+		c.Error(err)
+		return
+	}
+	page, err := h.userUseCase.GetAllUsers(filter, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, dto.ToUserResponseList(page.Users))
 }
 
 // UpdateUser はユーザー情報を更新する
 func (h *UserHandler) UpdateUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.updateUser(c, id)
+}
+
+// RemoveUser はユーザーを無効化する
+// クエリパラメータ purge=true を指定するとユーザーとそのTodoを完全に削除する
+func (h *UserHandler) RemoveUser(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if c.Query("purge") == "true" {
+		err = h.userUseCase.PurgeUser(id)
+	} else {
+		err = h.userUseCase.DeactivateUser(id)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ChangeUserRole はユーザーのロールを変更する
+func (h *UserHandler) ChangeUserRole(c *gin.Context) {
+	currentUserID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	user, err := h.userUseCase.ChangeUserRole(id, input.Role, currentUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
+}
+
+// GetMe は現在ログイン中のユーザーの情報を取得する
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
+	user, err := h.userUseCase.GetUserByID(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
+}
+
+// UpdateMe は現在ログイン中のユーザーの情報を更新する
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.updateUser(c, userID)
+}
+
+// DeleteMe は現在ログイン中のユーザーを無効化する
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.userUseCase.DeactivateUser(userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// updateUser はリクエストボディの内容で指定されたユーザーの情報を更新する
+func (h *UserHandler) updateUser(c *gin.Context, id uint) {
 	var input struct {
 		Username string `json:"username"`
-		Password string `json:"password"`
 		Email    string `json:"email" binding:"email"`
 	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
 		return
 	}
-	user, err := h.userUseCase.UpdateUser(uint(id), input.Username, input.Password, input.Email)
+	user, err := h.userUseCase.UpdateUser(id, input.Username, input.Email)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.ToUserResponse(user))
 }
 
-// RemoveUser はユーザーを削除する
-func (h *UserHandler) RemoveUser(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+// ReactivateUser は無効化されたユーザーを再び有効にする
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	id, err := parseID(c, "id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なIDです"})
+		c.Error(err)
 		return
 	}
-	err = h.userUseCase.RemoveUser(uint(id))
+	user, err := h.userUseCase.ReactivateUser(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	c.JSON(http.StatusOK, dto.ToUserResponse(user))
 }
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/dto"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+)
+
+// UserSettingsHandler はログインユーザーの設定関連のHTTPリクエストを処理します
+type UserSettingsHandler struct {
+	settingsUseCase *usecase.UserSettingsUseCase
+}
+
+// NewUserSettingsHandler は新しいUserSettingsHandlerのインスタンスを作成します
+func NewUserSettingsHandler(settingsUseCase *usecase.UserSettingsUseCase) *UserSettingsHandler {
+	return &UserSettingsHandler{
+		settingsUseCase: settingsUseCase,
+	}
+}
+
+// GetSettings は現在ログイン中のユーザーの設定を取得するエンドポイント
+func (h *UserSettingsHandler) GetSettings(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	settings, err := h.settingsUseCase.GetSettings(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToUserSettingsResponse(settings))
+}
+
+// UpdateSettings は現在ログイン中のユーザーの設定を置き換えるエンドポイント
+// 省略した項目は既定値に戻ります
+func (h *UserSettingsHandler) UpdateSettings(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var input struct {
+		Timezone         string `json:"timezone"`
+		Locale           string `json:"locale"`
+		DefaultSort      string `json:"default_sort"`
+		WeekStart        string `json:"week_start"`
+		DefaultProjectID *uint  `json:"default_project_id"`
+	}
+	if err := bindJSON(c, &input); err != nil {
+		c.Error(err)
+		return
+	}
+	settings, err := h.settingsUseCase.UpdateSettings(userID, usecase.UpdateUserSettingsInput{
+		Timezone:         input.Timezone,
+		Locale:           input.Locale,
+		DefaultSort:      input.DefaultSort,
+		WeekStart:        input.WeekStart,
+		DefaultProjectID: input.DefaultProjectID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToUserSettingsResponse(settings))
+}
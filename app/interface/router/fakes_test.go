@@ -0,0 +1,227 @@
+package router
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+)
+
+// テストで使用するメモリ上のリポジトリです
+// 埋め込んだインターフェースはnilのため、テストで使用しないメソッドを呼び出すとpanicします
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[uint]*model.User
+}
+
+func (r *fakeUserRepository) FindByID(id uint) (*model.User, error) {
+	if user := r.users[id]; user != nil && !user.DeleteFlag {
+		return user, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) FindDeactivatedByID(id uint) (*model.User, error) {
+	if user := r.users[id]; user != nil && user.DeleteFlag {
+		return user, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) FindByUsername(username string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Username == username && !user.DeleteFlag {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) Deactivate(id uint) error {
+	now := time.Now()
+	r.users[id].DeleteFlag = true
+	r.users[id].DeactivatedAt = &now
+	return nil
+}
+
+func (r *fakeUserRepository) Reactivate(id uint) error {
+	r.users[id].DeleteFlag = false
+	r.users[id].DeactivatedAt = nil
+	return nil
+}
+
+func (r *fakeUserRepository) RevokeTokensBefore(id uint, before time.Time) error {
+	r.users[id].TokensRevokedAt = &before
+	return nil
+}
+
+type fakeUserSettingsRepository struct {
+	repository.UserSettingsRepository
+}
+
+func (r *fakeUserSettingsRepository) FindByUserID(userID uint) (*model.UserSettings, error) {
+	return nil, nil
+}
+
+type fakeRevokedTokenRepository struct {
+	repository.RevokedTokenRepository
+}
+
+func (r *fakeRevokedTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	return false, nil
+}
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions []*model.Session
+}
+
+func (r *fakeSessionRepository) FindByID(id uint) (*model.Session, error) {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) FindByFamilyID(familyID string) (*model.Session, error) {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) Create(session *model.Session) error {
+	session.ID = uint(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeSessionRepository) Touch(id uint, seenAt time.Time) error {
+	return nil
+}
+
+func (r *fakeSessionRepository) Revoke(id uint) error {
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.ID == id && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeByUserIDBefore(userID uint, before time.Time) error {
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.CreatedAt.Before(before) && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	tokens []*model.RefreshToken
+}
+
+func (r *fakeRefreshTokenRepository) FindByTokenHash(tokenHash string) (*model.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRefreshTokenRepository) Create(token *model.RefreshToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil {
+			token.UsedAt = &usedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(familyID string) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeByUserIDBefore(userID uint, before time.Time) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.CreatedAt.Before(before) && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeOAuthClientRepository struct {
+	repository.OAuthClientRepository
+	clients []*model.OAuthClient
+}
+
+func (r *fakeOAuthClientRepository) FindByClientID(clientID string) (*model.OAuthClient, error) {
+	for _, client := range r.clients {
+		if client.ClientID == clientID {
+			return client, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOAuthClientRepository) Create(client *model.OAuthClient) error {
+	client.ID = uint(len(r.clients) + 1)
+	r.clients = append(r.clients, client)
+	return nil
+}
+
+type fakeOAuthAuthorizationCodeRepository struct {
+	repository.OAuthAuthorizationCodeRepository
+	codes []*model.OAuthAuthorizationCode
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) FindByCodeHash(codeHash string) (*model.OAuthAuthorizationCode, error) {
+	for _, code := range r.codes {
+		if code.CodeHash == codeHash {
+			return code, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) Create(code *model.OAuthAuthorizationCode) error {
+	code.ID = uint(len(r.codes) + 1)
+	r.codes = append(r.codes, code)
+	return nil
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) MarkUsed(id uint, familyID string, usedAt time.Time) (bool, error) {
+	for _, code := range r.codes {
+		if code.ID == id && code.UsedAt == nil {
+			code.UsedAt = &usedAt
+			code.FamilyID = familyID
+			return true, nil
+		}
+	}
+	return false, nil
+}
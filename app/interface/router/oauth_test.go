@@ -0,0 +1,332 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
+	"github.com/jugeeem/golang-todo.git/app/interface/handler"
+	"github.com/jugeeem/golang-todo.git/app/usecase"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+)
+
+const (
+	testUsername     = "alice"
+	testPassword     = "correct-horse-battery"
+	testRedirectURI  = "https://client.example.com/callback"
+	testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// oauthServer はメモリ上のリポジトリでサインイン、ユーザーの無効化とOAuthの認可コードフローに必要なエンドポイントを提供するテスト用のサーバーです
+type oauthServer struct {
+	engine        *gin.Engine
+	userUseCase   *usecase.UserUseCase
+	clientRepo    *fakeOAuthClientRepository
+	sessionRepo   *fakeSessionRepository
+	refreshTokens *fakeRefreshTokenRepository
+}
+
+// テスト用のパスワードのハッシュです。ハッシュ化には時間がかかるため、全てのテストで共有します
+var (
+	testPasswordOnce sync.Once
+	testPasswordHash string
+	testPepperID     string
+	testPasswordErr  error
+)
+
+func newOAuthServer(t *testing.T) *oauthServer {
+	t.Helper()
+	testPasswordOnce.Do(func() {
+		testPasswordHash, testPepperID, testPasswordErr = utility.HashPassword(testPassword)
+	})
+	if testPasswordErr != nil {
+		t.Fatal(testPasswordErr)
+	}
+	user := model.NewUser(testUsername, testPasswordHash, testPepperID, "alice@example.com")
+	user.ID = 1
+	userRepo := &fakeUserRepository{users: map[uint]*model.User{user.ID: user}}
+	s := &oauthServer{
+		clientRepo:    &fakeOAuthClientRepository{},
+		sessionRepo:   &fakeSessionRepository{},
+		refreshTokens: &fakeRefreshTokenRepository{},
+	}
+	revokedTokenRepo := &fakeRevokedTokenRepository{}
+	authUseCase := usecase.NewAuthUseCase(userRepo, s.refreshTokens, revokedTokenRepo, s.sessionRepo)
+	s.userUseCase = usecase.NewUserUseCase(userRepo, authUseCase)
+	oauthUseCase := usecase.NewOAuthUseCase(s.clientRepo, &fakeOAuthAuthorizationCodeRepository{}, userRepo, s.sessionRepo, authUseCase)
+	s.engine = SetupRouter(
+		middleware.JWTAuthMiddleware(userRepo, revokedTokenRepo, s.sessionRepo, nil),
+		middleware.UserLocale(&fakeUserSettingsRepository{}),
+		handler.NewUserHandler(s.userUseCase),
+		handler.NewAuthHandler(authUseCase),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		handler.NewOAuthHandler(oauthUseCase),
+	)
+	return s
+}
+
+// registerClient はクライアントを登録し、クライアントIDを返します。secretが空でない場合は機密クライアントにします
+func (s *oauthServer) registerClient(t *testing.T, secret string) string {
+	t.Helper()
+	var secretHash string
+	if secret != "" {
+		secretHash = utility.HashToken(secret)
+	}
+	clientID, err := utility.GenerateRandomToken(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := model.NewOAuthClient(clientID, secretHash, "CLI", []string{testRedirectURI}, []model.Scope{model.ScopeTodosRead})
+	if err := s.clientRepo.Create(client); err != nil {
+		t.Fatal(err)
+	}
+	return clientID
+}
+
+func (s *oauthServer) do(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func (s *oauthServer) postJSON(t *testing.T, path, accessToken string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return s.do(req)
+}
+
+func (s *oauthServer) postToken(form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return s.do(req)
+}
+
+// signin はユーザーとしてサインインし、アクセストークンを返します
+func (s *oauthServer) signin(t *testing.T) string {
+	t.Helper()
+	w := s.postJSON(t, "/api/v1/token", "", map[string]string{"username": testUsername, "password": testPassword})
+	if w.Code != http.StatusOK {
+		t.Fatalf("signin: status = %d, body = %s", w.Code, w.Body)
+	}
+	return decode(t, w)["token"].(string)
+}
+
+// authorize はユーザーとしてクライアントに同意し、発行された認可コードを返します
+func (s *oauthServer) authorize(t *testing.T, accessToken, clientID string) string {
+	t.Helper()
+	w := s.postJSON(t, "/oauth/authorize", accessToken, map[string]any{
+		"response_type":         "code",
+		"client_id":             clientID,
+		"redirect_uri":          testRedirectURI,
+		"scope":                 string(model.ScopeTodosRead),
+		"state":                 "xyz",
+		"code_challenge":        utility.PKCEChallenge(testCodeVerifier),
+		"code_challenge_method": "S256",
+		"approve":               true,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("authorize: status = %d, body = %s", w.Code, w.Body)
+	}
+	redirectTo, err := url.Parse(decode(t, w)["redirect_to"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := redirectTo.Query().Get("state"); got != "xyz" {
+		t.Errorf("state = %q, want xyz", got)
+	}
+	code := redirectTo.Query().Get("code")
+	if code == "" {
+		t.Fatalf("redirect_to %s has no code", redirectTo)
+	}
+	return code
+}
+
+func exchangeForm(clientID, code, verifier string) url.Values {
+	return url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {clientID},
+		"code":          {code},
+		"redirect_uri":  {testRedirectURI},
+		"code_verifier": {verifier},
+	}
+}
+
+func refreshForm(clientID, refreshToken string) url.Values {
+	return url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"refresh_token": {refreshToken},
+	}
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", w.Body, err)
+	}
+	return body
+}
+
+func assertOAuthError(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d, body = %s", w.Code, status, w.Body)
+	}
+	if got := decode(t, w)["error"]; got != code {
+		t.Errorf("error = %v, want %s", got, code)
+	}
+}
+
+func TestOAuthAuthorizationCodeFlow(t *testing.T) {
+	s := newOAuthServer(t)
+	clientID := s.registerClient(t, "")
+	code := s.authorize(t, s.signin(t), clientID)
+
+	w := s.postToken(exchangeForm(clientID, code, testCodeVerifier))
+	if w.Code != http.StatusOK {
+		t.Fatalf("token: status = %d, body = %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+	issued := decode(t, w)
+	if issued["token_type"] != "Bearer" || issued["scope"] != string(model.ScopeTodosRead) {
+		t.Errorf("token response = %v", issued)
+	}
+	refreshToken := issued["refresh_token"].(string)
+
+	// OAuthクライアントのリフレッシュトークンはクライアント認証のない公開のエンドポイントでは使えず、使用済みにもなりません
+	w = s.postJSON(t, "/api/v1/token/refresh", "", map[string]string{"refresh_token": refreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("public refresh: status = %d, want 401, body = %s", w.Code, w.Body)
+	}
+
+	w = s.postToken(refreshForm(clientID, refreshToken))
+	if w.Code != http.StatusOK {
+		t.Fatalf("refresh: status = %d, body = %s", w.Code, w.Body)
+	}
+	refreshed := decode(t, w)
+	if refreshed["refresh_token"] == refreshToken || refreshed["access_token"] == issued["access_token"] {
+		t.Errorf("refresh returned the same tokens: %v", refreshed)
+	}
+	if refreshed["scope"] != string(model.ScopeTodosRead) {
+		t.Errorf("scope = %v, want %s", refreshed["scope"], model.ScopeTodosRead)
+	}
+}
+
+func TestOAuthTokenRejectsLoginRefreshToken(t *testing.T) {
+	s := newOAuthServer(t)
+	clientID := s.registerClient(t, "")
+	w := s.postJSON(t, "/api/v1/token", "", map[string]string{"username": testUsername, "password": testPassword})
+	if w.Code != http.StatusOK {
+		t.Fatalf("signin: status = %d, body = %s", w.Code, w.Body)
+	}
+
+	w = s.postToken(refreshForm(clientID, decode(t, w)["refresh_token"].(string)))
+	assertOAuthError(t, w, http.StatusBadRequest, usecase.OAuthErrorInvalidGrant)
+}
+
+func TestOAuthTokenRejectsMismatchedCodeVerifier(t *testing.T) {
+	s := newOAuthServer(t)
+	clientID := s.registerClient(t, "")
+	code := s.authorize(t, s.signin(t), clientID)
+
+	w := s.postToken(exchangeForm(clientID, code, strings.Repeat("a", 43)))
+	assertOAuthError(t, w, http.StatusBadRequest, usecase.OAuthErrorInvalidGrant)
+
+	// 検証に失敗した認可コードは使用済みにならず、正しいcode_verifierで交換できます
+	w = s.postToken(exchangeForm(clientID, code, testCodeVerifier))
+	if w.Code != http.StatusOK {
+		t.Fatalf("token: status = %d, body = %s", w.Code, w.Body)
+	}
+}
+
+func TestOAuthTokenRevokesTokensOnCodeReuse(t *testing.T) {
+	s := newOAuthServer(t)
+	clientID := s.registerClient(t, "")
+	code := s.authorize(t, s.signin(t), clientID)
+	w := s.postToken(exchangeForm(clientID, code, testCodeVerifier))
+	if w.Code != http.StatusOK {
+		t.Fatalf("token: status = %d, body = %s", w.Code, w.Body)
+	}
+	issued := decode(t, w)
+
+	w = s.postToken(exchangeForm(clientID, code, testCodeVerifier))
+	assertOAuthError(t, w, http.StatusBadRequest, usecase.OAuthErrorInvalidGrant)
+
+	// 最初の交換で発行したリフレッシュトークンとアクセストークンは失効しています
+	w = s.postToken(refreshForm(clientID, issued["refresh_token"].(string)))
+	assertOAuthError(t, w, http.StatusBadRequest, usecase.OAuthErrorInvalidGrant)
+	req := httptest.NewRequest(http.MethodGet, "/oauth/authorize", nil)
+	req.Header.Set("Authorization", "Bearer "+issued["access_token"].(string))
+	if w := s.do(req); w.Code != http.StatusUnauthorized {
+		t.Errorf("access token after code reuse: status = %d, want 401, body = %s", w.Code, w.Body)
+	}
+}
+
+func TestOAuthTokenRejectsWrongClientSecret(t *testing.T) {
+	const secret = "s3cr3t"
+	tests := []struct {
+		name        string
+		formSecret  string
+		basicSecret string
+	}{
+		{name: "パラメータで送ったシークレットが誤っている", formSecret: "wrong"},
+		{name: "Basic認証で送ったシークレットが誤っている", basicSecret: "wrong"},
+		{name: "シークレットがない"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOAuthServer(t)
+			clientID := s.registerClient(t, secret)
+			code := s.authorize(t, s.signin(t), clientID)
+			form := exchangeForm(clientID, code, testCodeVerifier)
+			if tt.formSecret != "" {
+				form.Set("client_secret", tt.formSecret)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicSecret != "" {
+				req.SetBasicAuth(clientID, tt.basicSecret)
+			}
+
+			w := s.do(req)
+			assertOAuthError(t, w, http.StatusUnauthorized, usecase.OAuthErrorInvalidClient)
+			if got := w.Header().Get("WWW-Authenticate"); got == "" {
+				t.Error("WWW-Authenticate header is missing")
+			}
+
+			// 正しいシークレットであれば同じ認可コードを交換できます
+			form.Set("client_secret", secret)
+			if w := s.postToken(form); w.Code != http.StatusOK {
+				t.Fatalf("token with the right secret: status = %d, body = %s", w.Code, w.Body)
+			}
+		})
+	}
+}
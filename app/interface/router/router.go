@@ -3,48 +3,125 @@ package router
 import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
 	"github.com/jugeeem/golang-todo.git/app/interface/handler"
 )
 
 // SetupRouter はアプリケーションのルーターを設定します
 func SetupRouter(
+	authMiddleware gin.HandlerFunc,
+	localeMiddleware gin.HandlerFunc,
 	userHandler *handler.UserHandler,
 	authHandler *handler.AuthHandler,
 	todoHandler *handler.TodoHandler,
+	tagHandler *handler.TagHandler,
+	projectHandler *handler.ProjectHandler,
+	sessionHandler *handler.SessionHandler,
+	settingsHandler *handler.UserSettingsHandler,
+	accessTokenHandler *handler.PersonalAccessTokenHandler,
+	oauthHandler *handler.OAuthHandler,
 ) *gin.Engine {
 	r := gin.Default()
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://web:3000"}, // フロントエンドのオリジン
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Set-Cookie"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Set-Cookie", "Link", "X-Total-Count", "X-Next-Cursor"},
 		AllowCredentials: true,         // Cookieの送受信を許可
 		MaxAge:           12 * 60 * 60, // プリフライトリクエストのキャッシュ時間（12時間）
 	}))
+	r.Use(middleware.Localization(), middleware.ErrorHandler())
+	r.NoRoute(middleware.NoRouteHandler)
+	r.GET("/.well-known/jwks.json", authHandler.JWKS)
+	adminOnly := middleware.RequireRole(model.RoleAdmin)
+	// 個人用アクセストークンとOAuthクライアントのトークンはスコープを指定したエンドポイントでのみ使用でき、それ以外はログインが必要です
+	sessionOnly := middleware.RequireSession()
+	todoScope := middleware.RequireScopeByMethod(model.ScopeTodosRead, model.ScopeTodosWrite)
+	oauth := r.Group("/oauth")
+	{
+		oauth.POST("/token", oauthHandler.Token)
+		oauth.GET("/authorize", authMiddleware, localeMiddleware, sessionOnly, oauthHandler.GetAuthorization)
+		oauth.POST("/authorize", authMiddleware, localeMiddleware, sessionOnly, oauthHandler.Authorize)
+	}
 	public := r.Group("/api/v1")
 	{
 		public.POST("/token", authHandler.Signin)
+		public.POST("/token/refresh", authHandler.Refresh)
 		public.POST("/register", userHandler.CreateUser)
 	}
 	authorized := r.Group("/api/v1")
-	authorized.Use(middleware.JWTAuthMiddleware())
+	authorized.Use(authMiddleware, localeMiddleware)
 	{
+		authorized.POST("/logout", sessionOnly, authHandler.Logout)
+		authorized.POST("/logout/all", sessionOnly, authHandler.LogoutEverywhere)
+		me := authorized.Group("/me")
+		me.Use(sessionOnly)
+		{
+			me.GET("", userHandler.GetMe)
+			me.PUT("", userHandler.UpdateMe)
+			me.DELETE("", userHandler.DeleteMe)
+			me.POST("/password", authHandler.ChangePassword)
+			me.GET("/sessions", sessionHandler.GetSessions)
+			me.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+			me.GET("/settings", settingsHandler.GetSettings)
+			me.PUT("/settings", settingsHandler.UpdateSettings)
+			me.GET("/tokens", accessTokenHandler.GetTokens)
+			me.POST("/tokens", accessTokenHandler.CreateToken)
+			me.DELETE("/tokens/:id", accessTokenHandler.DeleteToken)
+		}
+		authorized.GET("/me/todos", todoScope, todoHandler.GetTodosByUser)
 		users := authorized.Group("/users")
+		users.Use(adminOnly, middleware.RequireScope(model.ScopeUsersAdmin))
 		{
 			users.GET("/", userHandler.GetAllUsers)
 			users.GET("/:id", userHandler.GetUserByID)
 			users.PUT("/:id", userHandler.UpdateUser)
 			users.DELETE("/:id", userHandler.RemoveUser)
+			users.POST("/:id/reactivate", userHandler.ReactivateUser)
+			users.PUT("/:id/role", userHandler.ChangeUserRole)
+		}
+		oauthClients := authorized.Group("/oauth/clients")
+		oauthClients.Use(sessionOnly, adminOnly)
+		{
+			oauthClients.GET("", oauthHandler.GetClients)
+			oauthClients.POST("", oauthHandler.RegisterClient)
+			oauthClients.DELETE("/:id", oauthHandler.DeleteClient)
 		}
 		todos := authorized.Group("/todos")
+		todos.Use(todoScope)
 		{
-			todos.GET("/", todoHandler.GetAllTodos)
+			todos.GET("/", adminOnly, todoHandler.GetAllTodos)
 			todos.POST("/", todoHandler.CreateTodo)
 			todos.GET("/:id", todoHandler.GetTodoByID)
 			todos.PUT("/:id", todoHandler.UpdateTodo)
 			todos.DELETE("/:id", todoHandler.DeleteTodo)
 			todos.GET("/my", todoHandler.GetTodosByUser)
+			todos.GET("/search", todoHandler.SearchTodos)
+			todos.GET("/trash", todoHandler.GetTrashedTodos)
+			todos.POST("/:id/restore", todoHandler.RestoreTodo)
+			todos.PUT("/:id/project", todoHandler.MoveTodo)
+			todos.POST("/:id/tags/:tagId", tagHandler.AttachTag)
+			todos.DELETE("/:id/tags/:tagId", tagHandler.DetachTag)
+		}
+		tags := authorized.Group("/tags")
+		tags.Use(todoScope)
+		{
+			tags.GET("/", tagHandler.GetTags)
+			tags.POST("/", tagHandler.CreateTag)
+			tags.PUT("/:id", tagHandler.UpdateTag)
+			tags.DELETE("/:id", tagHandler.DeleteTag)
+		}
+		projects := authorized.Group("/projects")
+		projects.Use(todoScope)
+		{
+			projects.GET("/", projectHandler.GetProjects)
+			projects.POST("/", projectHandler.CreateProject)
+			projects.GET("/:id", projectHandler.GetProjectByID)
+			projects.PUT("/:id", projectHandler.UpdateProject)
+			projects.POST("/:id/archive", projectHandler.ArchiveProject)
+			projects.POST("/:id/unarchive", projectHandler.UnarchiveProject)
+			projects.DELETE("/:id", projectHandler.DeleteProject)
 		}
 	}
 
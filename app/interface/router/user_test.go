@@ -0,0 +1,37 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReactivatedUserCannotUseTokensIssuedBeforeDeactivation(t *testing.T) {
+	s := newOAuthServer(t)
+	w := s.postJSON(t, "/api/v1/token", "", map[string]string{"username": testUsername, "password": testPassword})
+	if w.Code != http.StatusOK {
+		t.Fatalf("signin: status = %d, body = %s", w.Code, w.Body)
+	}
+	issued := decode(t, w)
+	accessToken := issued["token"].(string)
+	refreshToken := issued["refresh_token"].(string)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if w := s.do(req); w.Code != http.StatusNoContent {
+		t.Fatalf("delete me: status = %d, body = %s", w.Code, w.Body)
+	}
+	if _, err := s.userUseCase.ReactivateUser(1); err != nil {
+		t.Fatalf("ReactivateUser: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if w := s.do(req); w.Code != http.StatusUnauthorized {
+		t.Errorf("access token after reactivation: status = %d, want 401, body = %s", w.Code, w.Body)
+	}
+	w = s.postJSON(t, "/api/v1/token/refresh", "", map[string]string{"refresh_token": refreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh token after reactivation: status = %d, want 401, body = %s", w.Code, w.Body)
+	}
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	"log"
+	"strconv"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 
 	"github.com/jugeeem/golang-todo.git/app/infrastructure"
+	"github.com/jugeeem/golang-todo.git/app/infrastructure/middleware"
 	"github.com/jugeeem/golang-todo.git/app/infrastructure/persistence"
 	"github.com/jugeeem/golang-todo.git/app/interface/handler"
 	"github.com/jugeeem/golang-todo.git/app/interface/router"
@@ -28,21 +31,112 @@ func main() {
 	defer sqlDB.Close()
 	userRepo := persistence.NewUserRepository(gormDB)
 	todoRepo := persistence.NewTodoRepository(gormDB)
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo)
-	todoUseCase := usecase.NewTodoUseCase(todoRepo)
+	tagRepo := persistence.NewTagRepository(gormDB)
+	projectRepo := persistence.NewProjectRepository(gormDB)
+	refreshTokenRepo := persistence.NewRefreshTokenRepository(gormDB)
+	revokedTokenRepo := persistence.NewRevokedTokenRepository(gormDB)
+	sessionRepo := persistence.NewSessionRepository(gormDB)
+	settingsRepo := persistence.NewUserSettingsRepository(gormDB)
+	accessTokenRepo := persistence.NewPersonalAccessTokenRepository(gormDB)
+	oauthClientRepo := persistence.NewOAuthClientRepository(gormDB)
+	oauthCodeRepo := persistence.NewOAuthAuthorizationCodeRepository(gormDB)
+	authUseCase := usecase.NewAuthUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, sessionRepo)
+	userUseCase := usecase.NewUserUseCase(userRepo, authUseCase)
+	todoUseCase := usecase.NewTodoUseCase(todoRepo, projectRepo, tagRepo, settingsRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, todoRepo)
+	projectUseCase := usecase.NewProjectUseCase(projectRepo)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, refreshTokenRepo)
+	settingsUseCase := usecase.NewUserSettingsUseCase(settingsRepo, projectRepo)
+	accessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(accessTokenRepo, userRepo)
+	oauthUseCase := usecase.NewOAuthUseCase(oauthClientRepo, oauthCodeRepo, userRepo, sessionRepo, authUseCase)
 	userHandler := handler.NewUserHandler(userUseCase)
 	authHandler := handler.NewAuthHandler(authUseCase)
 	todoHandler := handler.NewTodoHandler(todoUseCase)
-	router := router.SetupRouter(userHandler, authHandler, todoHandler)
+	tagHandler := handler.NewTagHandler(tagUseCase)
+	projectHandler := handler.NewProjectHandler(projectUseCase)
+	sessionHandler := handler.NewSessionHandler(sessionUseCase)
+	settingsHandler := handler.NewUserSettingsHandler(settingsUseCase)
+	accessTokenHandler := handler.NewPersonalAccessTokenHandler(accessTokenUseCase)
+	oauthHandler := handler.NewOAuthHandler(oauthUseCase)
+	authMiddleware := middleware.JWTAuthMiddleware(userRepo, revokedTokenRepo, sessionRepo, accessTokenRepo)
+	localeMiddleware := middleware.UserLocale(settingsRepo)
+	router := router.SetupRouter(
+		authMiddleware,
+		localeMiddleware,
+		userHandler,
+		authHandler,
+		todoHandler,
+		tagHandler,
+		projectHandler,
+		sessionHandler,
+		settingsHandler,
+		accessTokenHandler,
+		oauthHandler,
+	)
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status": "ok",
 		})
 	})
+	trashRetention := retentionFromEnv("TRASH_RETENTION_DAYS", 30)
+	accountRetention := retentionFromEnv("ACCOUNT_RETENTION_DAYS", 90)
+	go runPeriodically(time.Hour, func() {
+		purged, err := todoUseCase.PurgeTrash(trashRetention)
+		if err != nil {
+			log.Printf("ゴミ箱の削除に失敗しました: %v", err)
+		} else if purged > 0 {
+			log.Printf("ゴミ箱から%d件のTodoを完全に削除しました", purged)
+		}
+	})
+	go runPeriodically(time.Hour, func() {
+		purged, err := userUseCase.PurgeDeactivatedUsers(accountRetention)
+		if err != nil {
+			log.Printf("無効化されたユーザーの削除に失敗しました: %v", err)
+		} else if purged > 0 {
+			log.Printf("無効化されたユーザー%d件を完全に削除しました", purged)
+		}
+	})
+	go runPeriodically(time.Hour, func() {
+		purged, err := authUseCase.PurgeExpiredTokens()
+		if err != nil {
+			log.Printf("期限切れのトークンの削除に失敗しました: %v", err)
+		} else if purged > 0 {
+			log.Printf("期限切れのトークン%d件を削除しました", purged)
+		}
+	})
+	go runPeriodically(time.Hour, func() {
+		purged, err := oauthUseCase.PurgeExpiredCodes()
+		if err != nil {
+			log.Printf("期限切れの認可コードの削除に失敗しました: %v", err)
+		} else if purged > 0 {
+			log.Printf("期限切れの認可コード%d件を削除しました", purged)
+		}
+	})
 	port := utility.GetEnv("PORT", "8080")
 	log.Printf("サーバーを起動しています: :%s", port)
 	if err := router.Run(":" + port); err != nil {
 		log.Fatalf("サーバー起動エラー: %v", err)
 	}
 }
+
+// retentionFromEnv は環境変数から保持日数を取得し、期間として返します
+func retentionFromEnv(key string, defaultDays int) time.Duration {
+	days, err := strconv.Atoi(utility.GetEnv(key, strconv.Itoa(defaultDays)))
+	if err != nil || days <= 0 {
+		log.Printf("警告: %sの値が無効です。デフォルトの%d日を使用します。", key, defaultDays)
+		days = defaultDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// runPeriodically は指定された間隔で処理を繰り返し実行します
+// 起動直後に一度実行してから間隔を空けます
+func runPeriodically(interval time.Duration, task func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		task()
+		<-ticker.C
+	}
+}
@@ -1,67 +1,334 @@
 package usecase
 
 import (
-	"errors"
+	"log"
+	"time"
 
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/domain/repository"
 	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
 // AuthUseCase は認証関連のビジネスロジックを提供します
 type AuthUseCase struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	revokedTokenRepo repository.RevokedTokenRepository
+	sessionRepo      repository.SessionRepository
 }
 
+// ClientInfo はログインしたクライアントの情報です。セッションに記録されます
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// TokenPair はログインやトークンの再発行で発行されるトークンの組です
+// ExpiresInはアクセストークンの有効期間、ScopeはOAuthクライアントに認可されたスコープです
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Scope        string
+}
+
+// refreshTokenSize はリフレッシュトークンの乱数のバイト数です
+const refreshTokenSize = 32
+
 // NewAuthUseCase は新しいAuthUseCaseのインスタンスを作成します
-func NewAuthUseCase(userRepo repository.UserRepository) *AuthUseCase {
+func NewAuthUseCase(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	revokedTokenRepo repository.RevokedTokenRepository,
+	sessionRepo repository.SessionRepository,
+) *AuthUseCase {
 	return &AuthUseCase{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		sessionRepo:      sessionRepo,
 	}
 }
 
-// Signin はユーザー認証を行い、JWTトークンを返します
-func (uc *AuthUseCase) Signin(usernameOrEmail, password string) (string, error) {
+// Signin はユーザー認証を行い、新しいセッションを作成してアクセストークンとリフレッシュトークンを返します
+func (uc *AuthUseCase) Signin(usernameOrEmail, password string, client ClientInfo) (*TokenPair, error) {
 	var user *model.User
 	var err error
 	user, err = uc.userRepo.FindByUsername(usernameOrEmail)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
 		user, err = uc.userRepo.FindByEmail(usernameOrEmail)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 	if user == nil {
-		return "", errors.New("ユーザーが見つかりません")
+		return nil, domainerr.Unauthorized(i18n.UserNotFound)
+	}
+	if !utility.CheckPasswordHash(password, user.Password, user.PasswordPepperID) {
+		return nil, domainerr.Unauthorized(i18n.PasswordIncorrect)
+	}
+	if err := uc.rehashPassword(user, password); err != nil {
+		// パスワードは検証できているため、再ハッシュ化に失敗してもサインインは続けます。次回のサインインで再び試みます
+		log.Printf("パスワードの再ハッシュ化に失敗しました（ユーザーID %d）: %v", user.ID, err)
+	}
+	familyID, err := utility.GenerateRandomToken(refreshTokenSize)
+	if err != nil {
+		return nil, err
+	}
+	session := model.NewSession(user.ID, familyID, client.UserAgent, client.IPAddress)
+	if err := uc.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return uc.issueTokens(user, session)
+}
+
+// rehashPassword は旧方式やローテーション前のペッパー、または現在の設定より弱いパラメータでハッシュ化されたパスワードを
+// 現在の設定で再ハッシュ化します
+// パスワードを検証できた直後にのみ呼び出します
+func (uc *AuthUseCase) rehashPassword(user *model.User, password string) error {
+	if !utility.PasswordNeedsRehash(user.Password, user.PasswordPepperID) {
+		return nil
+	}
+	hashedPassword, pepperID, err := utility.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.ChangePassword(hashedPassword, pepperID)
+	_, err = uc.userRepo.Update(user)
+
+	return err
+}
+
+// Refresh はリフレッシュトークンを使用済みにし、新しいアクセストークンとリフレッシュトークンを発行します
+// 使用済みのリフレッシュトークンが再び使われた場合は漏洩したとみなし、同じログインから発行された全てのトークンを失効させます
+// OAuthクライアントに認可したセッションのリフレッシュトークンは、クライアント認証を経ずに使われないよう受け付けません
+func (uc *AuthUseCase) Refresh(refreshToken string) (*TokenPair, error) {
+	return uc.refresh(refreshToken, nil)
+}
+
+// refresh はoauthClientIDのクライアントに認可したセッション（nilの場合はログインのセッション）のリフレッシュトークンを使ってトークンを再発行します
+// 他のセッションのリフレッシュトークンは使用済みにも再利用の検出の対象にもせずに拒否します
+func (uc *AuthUseCase) refresh(refreshToken string, oauthClientID *uint) (*TokenPair, error) {
+	stored, err := uc.refreshTokenRepo.FindByTokenHash(utility.HashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, domainerr.Unauthorized(i18n.RefreshTokenInvalid)
+	}
+	session, err := uc.sessionRepo.FindByFamilyID(stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil && !sameClient(session.OAuthClientID, oauthClientID) {
+		return nil, domainerr.Unauthorized(i18n.RefreshTokenInvalid)
+	}
+	if stored.UsedAt != nil {
+		return nil, uc.revokeReusedFamily(stored.FamilyID)
+	}
+	now := time.Now()
+	if !stored.IsUsable(now) {
+		return nil, domainerr.Unauthorized(i18n.RefreshTokenInvalid)
+	}
+	claimed, err := uc.refreshTokenRepo.MarkUsed(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, uc.revokeReusedFamily(stored.FamilyID)
+	}
+	if session == nil || session.IsRevoked() {
+		return nil, domainerr.Unauthorized(i18n.SessionEnded)
+	}
+	user, err := uc.userRepo.FindByID(stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.Unauthorized(i18n.AccountDisabled)
+	}
+	if err := uc.sessionRepo.Touch(session.ID, now); err != nil {
+		return nil, err
+	}
+
+	return uc.issueTokens(user, session)
+}
+
+// Logout は現在のアクセストークンを失効させ、そのセッションと同じログインから発行されたリフレッシュトークンを終了させます
+func (uc *AuthUseCase) Logout(claims *utility.JWTClaims) error {
+	revoked := model.NewRevokedToken(claims.ID, claims.UserID, claims.ExpiresAt.Time)
+	if err := uc.revokedTokenRepo.Revoke(revoked); err != nil {
+		return err
+	}
+	session, err := uc.sessionRepo.FindByID(claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != claims.UserID {
+		return nil
+	}
+	if err := uc.sessionRepo.Revoke(session.ID); err != nil {
+		return err
+	}
+
+	return uc.refreshTokenRepo.RevokeFamily(session.FamilyID)
+}
+
+// LogoutEverywhere は指定された日時より前にユーザーへ発行された全てのアクセストークンとリフレッシュトークンを失効させ、その前に作成されたセッションを終了させます
+// beforeがnilの場合は現在時刻を使います。未来の日時は指定できません
+func (uc *AuthUseCase) LogoutEverywhere(userID uint, before *time.Time) error {
+	now := time.Now()
+	if before == nil {
+		before = &now
+	}
+	if before.After(now) {
+		return domainerr.InvalidField("before", i18n.FutureTimeNotAllowed)
+	}
+	if err := uc.userRepo.RevokeTokensBefore(userID, *before); err != nil {
+		return err
+	}
+	if err := uc.sessionRepo.RevokeByUserIDBefore(userID, *before); err != nil {
+		return err
+	}
+
+	return uc.refreshTokenRepo.RevokeByUserIDBefore(userID, *before)
+}
+
+// ChangePassword は現在のパスワードを確認した上でパスワードを変更し、発行済みの全てのトークンとセッションを失効させます
+func (uc *AuthUseCase) ChangePassword(userID uint, currentPassword, newPassword string) error {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domainerr.Unauthorized(i18n.AccountDisabled)
+	}
+	if !utility.CheckPasswordHash(currentPassword, user.Password, user.PasswordPepperID) {
+		return domainerr.InvalidField("current_password", i18n.PasswordIncorrect)
+	}
+	if err := validatePassword("new_password", newPassword); err != nil {
+		return err
+	}
+	if newPassword == currentPassword {
+		return domainerr.InvalidField("new_password", i18n.PasswordUnchanged)
+	}
+	hashedPassword, pepperID, err := utility.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.ChangePassword(hashedPassword, pepperID)
+	if _, err := uc.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return uc.LogoutEverywhere(userID, nil)
+}
+
+// PurgeExpiredTokens は有効期限が切れたリフレッシュトークンと失効済みアクセストークンの記録、
+// およびリフレッシュトークンの有効期間を超えて使われていないセッションを削除し、削除件数を返します
+func (uc *AuthUseCase) PurgeExpiredTokens() (int64, error) {
+	now := time.Now()
+	purgedRefresh, err := uc.refreshTokenRepo.PurgeExpiredBefore(now)
+	if err != nil {
+		return 0, err
+	}
+	purgedRevoked, err := uc.revokedTokenRepo.PurgeExpiredBefore(now)
+	if err != nil {
+		return purgedRefresh, err
 	}
-	if !utility.CheckPasswordHash(password, user.Password) {
-		return "", errors.New("パスワードが正しくありません")
+	purgedSessions, err := uc.sessionRepo.PurgeInactiveBefore(now.Add(-utility.RefreshTokenTTL()))
+	if err != nil {
+		return purgedRefresh + purgedRevoked, err
+	}
+
+	return purgedRefresh + purgedRevoked + purgedSessions, nil
+}
+
+// issueTokens はセッションに紐づくアクセストークンとリフレッシュトークンを発行します
+func (uc *AuthUseCase) issueTokens(user *model.User, session *model.Session) (*TokenPair, error) {
+	accessToken, err := utility.GenerateToken(user.ID, user.Username, string(user.Role), session.ID, session.Scopes)
+	if err != nil {
+		return nil, err
 	}
-	token, err := utility.GenerateToken(user.ID, user.Username)
+	refreshToken, err := utility.GenerateRandomToken(refreshTokenSize)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	stored := model.NewRefreshToken(
+		user.ID,
+		session.FamilyID,
+		utility.HashToken(refreshToken),
+		time.Now().Add(utility.RefreshTokenTTL()),
+	)
+	if err := uc.refreshTokenRepo.Create(stored); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    utility.AccessTokenTTL(),
+		Scope:        session.Scopes,
+	}, nil
+}
+
+// sameClient は2つのOAuthクライアントのIDが同じか、どちらもnilかどうかを返します
+func sameClient(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
 
-	return token, nil
+	return *a == *b
+}
+
+// revokeReusedFamily はリフレッシュトークンの再利用を検出した際にファミリー全体とそのセッションを失効させ、返すエラーを作成します
+func (uc *AuthUseCase) revokeReusedFamily(familyID string) error {
+	if err := uc.revokeFamily(familyID); err != nil {
+		return err
+	}
+
+	return domainerr.Unauthorized(i18n.RefreshTokenReused)
+}
+
+// revokeFamily は指定されたファミリーの全てのリフレッシュトークンを失効させ、そのセッションを終了します
+func (uc *AuthUseCase) revokeFamily(familyID string) error {
+	if err := uc.refreshTokenRepo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	session, err := uc.sessionRepo.FindByFamilyID(familyID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return nil
+	}
+
+	return uc.sessionRepo.Revoke(session.ID)
 }
 
 // Register は新しいユーザーを登録します
 func (uc *AuthUseCase) Register(username, password, email string) (*model.User, error) {
-	existingUser, err := uc.userRepo.FindByUsernameOrEmail(username, email)
+	existingUser, err := uc.userRepo.FindByUsernameOrEmailWithDeactivated(username, email)
 	if err != nil {
 		return nil, err
 	}
 	if existingUser != nil {
-		return nil, errors.New("ユーザー名またはメールアドレスは既に使用されています")
+		return nil, domainerr.Conflict(i18n.UserAlreadyExists)
+	}
+	if err := validatePassword("password", password); err != nil {
+		return nil, err
 	}
-	hashedPassword, err := utility.HashPassword(password)
+	hashedPassword, pepperID, err := utility.HashPassword(password)
 	if err != nil {
 		return nil, err
 	}
-	user := model.NewUser(username, hashedPassword, email)
+	user := model.NewUser(username, hashedPassword, pepperID, email)
 	createdUser, err := uc.userRepo.Create(user)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// developmentPepperID と developmentPepper はPASSWORD_PEPPERが設定されていない場合に使用される開発用のペッパーです
+const (
+	developmentPepperID = "dev"
+	developmentPepper   = "fallback_development_pepper_do_not_use_in_production"
+)
+
+// weakPasswordHash は開発用のペッパーを適用したパスワードを最小のコストのbcryptでハッシュ化します
+// 現在の設定より弱いため、サインイン時に再ハッシュ化の対象になります
+func weakPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+	if os.Getenv("PASSWORD_PEPPER") != "" {
+		t.Skip("PASSWORD_PEPPERが設定されているため、開発用のペッパーでハッシュ化できません")
+	}
+	mac := hmac.New(sha256.New, []byte(developmentPepper))
+	mac.Write([]byte(password))
+	hash, err := utility.NewBcryptHasher(bcrypt.MinCost).Hash([]byte(base64.RawStdEncoding.EncodeToString(mac.Sum(nil))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestSigninRehashesWeakPasswordHash(t *testing.T) {
+	const password = "correct-horse-battery"
+	tests := []struct {
+		name        string
+		updateErr   error
+		wantUpdated int
+	}{
+		{name: "再ハッシュ化したパスワードを保存する", wantUpdated: 1},
+		{name: "再ハッシュ化の保存に失敗してもサインインできる", updateErr: errors.New("connection reset")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash := weakPasswordHash(t, password)
+			user := &model.User{ID: 1, Username: "alice", Email: "alice@example.com", Password: hash, PasswordPepperID: developmentPepperID}
+			userRepo := &fakeUserRepository{users: map[uint]*model.User{1: user}, updateErr: tt.updateErr}
+			uc := NewAuthUseCase(userRepo, &fakeRefreshTokenRepository{}, nil, &fakeSessionRepository{})
+
+			tokens, err := uc.Signin("alice", password, ClientInfo{})
+			if err != nil {
+				t.Fatalf("Signin() error = %v", err)
+			}
+			if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+				t.Errorf("Signin() = %+v, want access and refresh tokens", tokens)
+			}
+			if userRepo.updated != tt.wantUpdated {
+				t.Errorf("Update called %d times, want %d", userRepo.updated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+// racingRefreshTokenRepository は検索した時点では未使用でも、使用済みにする前に別のリクエストに使われたリフレッシュトークンを再現します
+type racingRefreshTokenRepository struct {
+	*fakeRefreshTokenRepository
+}
+
+func (r *racingRefreshTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	return false, nil
+}
+
+// newRefreshTestUseCase はユーザーのログインのセッションを作成し、そのセッションで発行したリフレッシュトークンを返します
+func newRefreshTestUseCase(t *testing.T, refreshTokenRepo repository.RefreshTokenRepository) (*AuthUseCase, *fakeSessionRepository, string) {
+	t.Helper()
+	user := &model.User{ID: 1, Username: "alice"}
+	userRepo := &fakeUserRepository{users: map[uint]*model.User{1: user}}
+	sessionRepo := &fakeSessionRepository{}
+	uc := NewAuthUseCase(userRepo, refreshTokenRepo, nil, sessionRepo)
+	session := model.NewSession(user.ID, "family-1", "", "")
+	if err := sessionRepo.Create(session); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := uc.issueTokens(user, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uc, sessionRepo, tokens.RefreshToken
+}
+
+func assertUnauthorized(t *testing.T, err error, want i18n.MessageID) {
+	t.Helper()
+	var domainErr *domainerr.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != domainerr.KindUnauthorized || domainErr.Message != want {
+		t.Fatalf("error = %v, want unauthorized %s", err, want)
+	}
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	refreshTokenRepo := &fakeRefreshTokenRepository{}
+	uc, _, refreshToken := newRefreshTestUseCase(t, refreshTokenRepo)
+
+	tokens, err := uc.Refresh(refreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tokens.RefreshToken == refreshToken {
+		t.Error("Refresh() returned the same refresh token")
+	}
+	if len(refreshTokenRepo.tokens) != 2 {
+		t.Fatalf("stored %d refresh tokens, want 2", len(refreshTokenRepo.tokens))
+	}
+	first, second := refreshTokenRepo.tokens[0], refreshTokenRepo.tokens[1]
+	if first.UsedAt == nil {
+		t.Error("the redeemed refresh token is not marked as used")
+	}
+	if second.FamilyID != first.FamilyID {
+		t.Errorf("rotated token family = %q, want %q", second.FamilyID, first.FamilyID)
+	}
+}
+
+func TestRefreshRevokesFamilyOnReuse(t *testing.T) {
+	refreshTokenRepo := &fakeRefreshTokenRepository{}
+	uc, sessionRepo, refreshToken := newRefreshTestUseCase(t, refreshTokenRepo)
+	rotated, err := uc.Refresh(refreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	_, err = uc.Refresh(refreshToken)
+	assertUnauthorized(t, err, i18n.RefreshTokenReused)
+	for _, token := range refreshTokenRepo.tokens {
+		if token.RevokedAt == nil {
+			t.Errorf("refresh token %d in the family is not revoked", token.ID)
+		}
+	}
+	if !sessionRepo.sessions[0].IsRevoked() {
+		t.Error("the session is not revoked")
+	}
+
+	// 再利用を検出した後は、正規の利用者が持つ最新のリフレッシュトークンも使えません
+	_, err = uc.Refresh(rotated.RefreshToken)
+	assertUnauthorized(t, err, i18n.RefreshTokenInvalid)
+}
+
+func TestRefreshRevokesFamilyWhenConcurrentlyRedeemed(t *testing.T) {
+	refreshTokenRepo := &racingRefreshTokenRepository{&fakeRefreshTokenRepository{}}
+	uc, sessionRepo, refreshToken := newRefreshTestUseCase(t, refreshTokenRepo)
+
+	_, err := uc.Refresh(refreshToken)
+	assertUnauthorized(t, err, i18n.RefreshTokenReused)
+	if refreshTokenRepo.tokens[0].RevokedAt == nil {
+		t.Error("the refresh token is not revoked")
+	}
+	if !sessionRepo.sessions[0].IsRevoked() {
+		t.Error("the session is not revoked")
+	}
+}
+
+func TestRefreshRejectsUnknownToken(t *testing.T) {
+	uc, _, _ := newRefreshTestUseCase(t, &fakeRefreshTokenRepository{})
+
+	_, err := uc.Refresh("unknown")
+	assertUnauthorized(t, err, i18n.RefreshTokenInvalid)
+}
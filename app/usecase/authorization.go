@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+var (
+	// ErrTodoNotFound はTodoが存在しない場合に返されます
+	ErrTodoNotFound = domainerr.NotFound(i18n.TodoNotFound)
+	// ErrTodoForbidden はTodoは存在するが、操作する権限がない場合に返されます
+	ErrTodoForbidden = domainerr.Forbidden(i18n.TodoForbidden)
+)
+
+// Actor は操作を行うユーザーです
+type Actor struct {
+	UserID uint
+	Role   model.Role
+}
+
+// todoAction はTodoに対する操作の種類です
+type todoAction int
+
+const (
+	// todoRead はTodoの参照です
+	todoRead todoAction = iota
+	// todoWrite はTodoの作成・更新・削除です
+	todoWrite
+)
+
+// canAccessTodo はユーザーがTodoに対して指定された操作を行えるかどうかを返します
+// 所有者は全ての操作を行え、管理者は他のユーザーのTodoを参照のみできます
+func (a Actor) canAccessTodo(todo *model.Todo, action todoAction) bool {
+	if todo.UserID == a.UserID {
+		return true
+	}
+
+	return action == todoRead && a.Role == model.RoleAdmin
+}
+
+// authorizeTodo は取得したTodoに対する操作の権限を確認します
+// Todoが存在しない場合はErrTodoNotFound、権限がない場合はErrTodoForbiddenを返します
+func authorizeTodo(todo *model.Todo, actor Actor, action todoAction) error {
+	if todo == nil {
+		return ErrTodoNotFound
+	}
+	if !actor.canAccessTodo(todo, action) {
+		return ErrTodoForbidden
+	}
+
+	return nil
+}
+
+// checkGrantableScopes はユーザーが個人用アクセストークンやOAuthクライアントに指定されたスコープを付与できるかを検証します
+// users:adminは管理者のみ付与できます
+func checkGrantableScopes(userRepo repository.UserRepository, userID uint, scopes []model.Scope) error {
+	user, err := userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domainerr.Unauthorized(i18n.AccountDisabled)
+	}
+	for _, scope := range scopes {
+		if scope == model.ScopeUsersAdmin && !user.IsAdmin() {
+			return domainerr.Forbidden(i18n.ScopeNotAllowed, scope)
+		}
+	}
+
+	return nil
+}
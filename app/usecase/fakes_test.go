@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+)
+
+// テストで使用するメモリ上のリポジトリです
+// 埋め込んだインターフェースはnilのため、テストで使用しないメソッドを呼び出すとpanicします
+
+type fakeTodoRepository struct {
+	repository.TodoRepository
+	todos   map[uint]*model.Todo
+	created []*model.Todo
+	updated int
+}
+
+func (r *fakeTodoRepository) FindByID(id uint) (*model.Todo, error) {
+	return r.todos[id], nil
+}
+
+func (r *fakeTodoRepository) Create(todo *model.Todo) error {
+	todo.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, todo)
+	return nil
+}
+
+func (r *fakeTodoRepository) Update(todo *model.Todo) error {
+	r.updated++
+	return nil
+}
+
+type fakeUserSettingsRepository struct {
+	repository.UserSettingsRepository
+	settings map[uint]*model.UserSettings
+}
+
+func (r *fakeUserSettingsRepository) FindByUserID(userID uint) (*model.UserSettings, error) {
+	return r.settings[userID], nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users     map[uint]*model.User
+	updateErr error
+	updated   int
+}
+
+func (r *fakeUserRepository) FindByID(id uint) (*model.User, error) {
+	return r.users[id], nil
+}
+
+func (r *fakeUserRepository) FindByUsername(username string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) FindByEmail(email string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) Update(user *model.User) (*model.User, error) {
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	r.updated++
+	return user, nil
+}
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions []*model.Session
+}
+
+func (r *fakeSessionRepository) FindByID(id uint) (*model.Session, error) {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) FindByFamilyID(familyID string) (*model.Session, error) {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) Create(session *model.Session) error {
+	session.ID = uint(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeSessionRepository) Touch(id uint, seenAt time.Time) error {
+	return nil
+}
+
+func (r *fakeSessionRepository) Revoke(id uint) error {
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.ID == id && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	tokens []*model.RefreshToken
+}
+
+func (r *fakeRefreshTokenRepository) FindByTokenHash(tokenHash string) (*model.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRefreshTokenRepository) Create(token *model.RefreshToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil {
+			token.UsedAt = &usedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(familyID string) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,467 @@
+package usecase
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+const (
+	// oauthClientNameMaxLength はOAuthクライアントの名前の最大文字数です
+	oauthClientNameMaxLength = 100
+	// oauthClientIDSize はクライアントIDの乱数のバイト数です
+	oauthClientIDSize = 16
+	// oauthClientSecretSize はクライアントシークレットの乱数のバイト数です
+	oauthClientSecretSize = 32
+	// authorizationCodeSize は認可コードの乱数のバイト数です
+	authorizationCodeSize = 32
+	// authorizationCodeTTL は認可コードの有効期間です
+	authorizationCodeTTL = 10 * time.Minute
+	// codeChallengeMethodS256 は対応しているPKCEのcode_challenge_methodです
+	codeChallengeMethodS256 = "S256"
+)
+
+// codeChallengePattern はS256方式のcode_challenge（SHA-256のBase64URL）の形式です
+var codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// codeVerifierPattern はRFC 7636で定められたcode_verifierの形式です
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+
+// RFC 6749で定められたトークンエンドポイントのエラーコードです
+const (
+	OAuthErrorInvalidRequest       = "invalid_request"
+	OAuthErrorInvalidClient        = "invalid_client"
+	OAuthErrorInvalidGrant         = "invalid_grant"
+	OAuthErrorUnsupportedGrantType = "unsupported_grant_type"
+	OAuthErrorAccessDenied         = "access_denied"
+)
+
+// OAuthError はトークンエンドポイントでRFC 6749の形式で返すエラーです
+type OAuthError struct {
+	Code    string
+	Message i18n.MessageID
+	Args    []any
+}
+
+// newOAuthError は新しいOAuthErrorを作成します
+func newOAuthError(code string, message i18n.MessageID, args ...any) *OAuthError {
+	return &OAuthError{Code: code, Message: message, Args: args}
+}
+
+// Localize は指定された言語でエラーの説明を返します
+func (e *OAuthError) Localize(lang i18n.Language) string {
+	return i18n.Translate(lang, e.Message, e.Args...)
+}
+
+// Error はエラーコードと既定の言語の説明を返します
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Localize(i18n.Default)
+}
+
+// OAuthUseCase はOAuth2の認可コードフロー（PKCE必須）のビジネスロジックを提供します
+// 認可したクライアントごとにセッションを作成し、トークンの発行と再発行はAuthUseCaseと同じ仕組みを使います
+type OAuthUseCase struct {
+	clientRepo  repository.OAuthClientRepository
+	codeRepo    repository.OAuthAuthorizationCodeRepository
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	authUseCase *AuthUseCase
+}
+
+// NewOAuthUseCase は新しいOAuthUseCaseのインスタンスを作成します
+func NewOAuthUseCase(
+	clientRepo repository.OAuthClientRepository,
+	codeRepo repository.OAuthAuthorizationCodeRepository,
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	authUseCase *AuthUseCase,
+) *OAuthUseCase {
+	return &OAuthUseCase{
+		clientRepo:  clientRepo,
+		codeRepo:    codeRepo,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		authUseCase: authUseCase,
+	}
+}
+
+// RegisterOAuthClientInput はOAuthクライアントの登録内容です
+// Confidentialがtrueの場合はクライアントシークレットを発行します
+type RegisterOAuthClientInput struct {
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+	Confidential bool
+}
+
+// AuthorizationRequest は認可エンドポイントへのリクエストのパラメータです
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// AuthorizationConsent はユーザーに同意を求める内容です
+type AuthorizationConsent struct {
+	Client      *model.OAuthClient
+	RedirectURI string
+	Scopes      []model.Scope
+	State       string
+}
+
+// TokenRequest はトークンエンドポイントへのリクエストのパラメータです
+type TokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	ClientID     string
+	ClientSecret string
+	CodeVerifier string
+	RefreshToken string
+}
+
+// GetClients は登録されている全てのOAuthクライアントを取得します
+func (uc *OAuthUseCase) GetClients() ([]*model.OAuthClient, error) {
+	return uc.clientRepo.FindAll()
+}
+
+// RegisterClient は新しいOAuthクライアントを登録し、登録したクライアントとクライアントシークレットを返します
+// クライアントシークレットは保存しないため登録時にのみ返します。公開クライアントの場合は空文字列です
+func (uc *OAuthUseCase) RegisterClient(input RegisterOAuthClientInput) (*model.OAuthClient, string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, "", domainerr.InvalidField("name", i18n.OAuthClientNameRequired)
+	}
+	if utf8.RuneCountInString(name) > oauthClientNameMaxLength {
+		return nil, "", domainerr.InvalidField("name", i18n.OAuthClientNameTooLong, oauthClientNameMaxLength)
+	}
+	if len(input.RedirectURIs) == 0 {
+		return nil, "", domainerr.InvalidField("redirect_uris", i18n.RedirectURIsRequired)
+	}
+	for _, uri := range input.RedirectURIs {
+		if !isValidRedirectURI(uri) {
+			return nil, "", domainerr.InvalidField("redirect_uris", i18n.InvalidRedirectURI, uri)
+		}
+	}
+	scopes, err := model.ParseScopes(input.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	clientID, err := utility.GenerateRandomToken(oauthClientIDSize)
+	if err != nil {
+		return nil, "", err
+	}
+	var clientSecret, clientSecretHash string
+	if input.Confidential {
+		clientSecret, err = utility.GenerateRandomToken(oauthClientSecretSize)
+		if err != nil {
+			return nil, "", err
+		}
+		clientSecretHash = utility.HashToken(clientSecret)
+	}
+	client := model.NewOAuthClient(clientID, clientSecretHash, name, input.RedirectURIs, scopes)
+	if err := uc.clientRepo.Create(client); err != nil {
+		return nil, "", err
+	}
+
+	return client, clientSecret, nil
+}
+
+// DeleteClient はOAuthクライアントを削除し、クライアントに認可したセッションを全て終了します
+func (uc *OAuthUseCase) DeleteClient(id uint) error {
+	client, err := uc.clientRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return domainerr.NotFound(i18n.OAuthClientNotFound)
+	}
+
+	return uc.clientRepo.Delete(client.ID)
+}
+
+// PrepareAuthorization は認可リクエストを検証し、ユーザーに同意を求める内容を返します
+// クライアントとリダイレクトURIが確認できない場合はリダイレクトせずにエラーを返す必要があるため、全ての検証エラーを入力値のエラーとして返します
+func (uc *OAuthUseCase) PrepareAuthorization(req AuthorizationRequest, userID uint) (*AuthorizationConsent, error) {
+	if req.ClientID == "" {
+		return nil, domainerr.InvalidField("client_id", i18n.OAuthParameterRequired, "client_id")
+	}
+	client, err := uc.clientRepo.FindByClientID(req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, domainerr.InvalidField("client_id", i18n.OAuthClientNotFound)
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return nil, domainerr.InvalidField("redirect_uri", i18n.RedirectURIMismatch)
+	}
+	if req.ResponseType != "code" {
+		return nil, domainerr.InvalidField("response_type", i18n.UnsupportedResponseType)
+	}
+	if req.CodeChallenge == "" {
+		return nil, domainerr.InvalidField("code_challenge", i18n.CodeChallengeRequired)
+	}
+	if req.CodeChallengeMethod != codeChallengeMethodS256 {
+		return nil, domainerr.InvalidField("code_challenge_method", i18n.UnsupportedChallengeMethod)
+	}
+	if !codeChallengePattern.MatchString(req.CodeChallenge) {
+		return nil, domainerr.InvalidField("code_challenge", i18n.FieldInvalid, "code_challenge")
+	}
+	scopes, err := model.ParseScopes(strings.Fields(req.Scope))
+	if err != nil {
+		return nil, err
+	}
+	allowed := client.ScopeList()
+	for _, scope := range scopes {
+		if !model.ContainsScope(allowed, scope) {
+			return nil, domainerr.InvalidField("scope", i18n.ScopeNotRegistered, scope)
+		}
+	}
+	if err := checkGrantableScopes(uc.userRepo, userID, scopes); err != nil {
+		return nil, err
+	}
+
+	return &AuthorizationConsent{
+		Client:      client,
+		RedirectURI: req.RedirectURI,
+		Scopes:      scopes,
+		State:       req.State,
+	}, nil
+}
+
+// Authorize はユーザーの同意の結果をクライアントに返すリダイレクト先のURIを返します
+// 同意した場合は認可コードを発行してcodeパラメータに、拒否した場合はerrorパラメータにaccess_deniedを設定します
+func (uc *OAuthUseCase) Authorize(req AuthorizationRequest, userID uint, approved bool) (string, error) {
+	consent, err := uc.PrepareAuthorization(req, userID)
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{}
+	if consent.State != "" {
+		params.Set("state", consent.State)
+	}
+	if !approved {
+		params.Set("error", OAuthErrorAccessDenied)
+		return withQuery(consent.RedirectURI, params)
+	}
+	code, err := utility.GenerateRandomToken(authorizationCodeSize)
+	if err != nil {
+		return "", err
+	}
+	stored := model.NewOAuthAuthorizationCode(
+		utility.HashToken(code),
+		consent.Client.ID,
+		userID,
+		consent.RedirectURI,
+		consent.Scopes,
+		req.CodeChallenge,
+		time.Now().Add(authorizationCodeTTL),
+	)
+	if err := uc.codeRepo.Create(stored); err != nil {
+		return "", err
+	}
+	params.Set("code", code)
+
+	return withQuery(consent.RedirectURI, params)
+}
+
+// Token はトークンエンドポイントのリクエストを処理し、アクセストークンとリフレッシュトークンを発行します
+// grant_typeにはauthorization_codeとrefresh_tokenを指定できます
+func (uc *OAuthUseCase) Token(req TokenRequest, clientInfo ClientInfo) (*TokenPair, error) {
+	switch req.GrantType {
+	case "authorization_code":
+		return uc.exchangeCode(req, clientInfo)
+	case "refresh_token":
+		return uc.refresh(req)
+	case "":
+		return nil, newOAuthError(OAuthErrorInvalidRequest, i18n.OAuthParameterRequired, "grant_type")
+	default:
+		return nil, newOAuthError(OAuthErrorUnsupportedGrantType, i18n.UnsupportedGrantType)
+	}
+}
+
+// PurgeExpiredCodes は有効期限が切れた認可コードを削除し、削除件数を返します
+func (uc *OAuthUseCase) PurgeExpiredCodes() (int64, error) {
+	return uc.codeRepo.PurgeExpiredBefore(time.Now())
+}
+
+// exchangeCode は認可コードとPKCEのcode_verifierを検証し、クライアントに認可したセッションを作成してトークンを発行します
+// 使用済みの認可コードが再び使われた場合は漏洩したとみなし、そのコードで発行したトークンを失効させます
+func (uc *OAuthUseCase) exchangeCode(req TokenRequest, clientInfo ClientInfo) (*TokenPair, error) {
+	client, err := uc.authenticateClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if req.Code == "" {
+		return nil, newOAuthError(OAuthErrorInvalidRequest, i18n.OAuthParameterRequired, "code")
+	}
+	if req.CodeVerifier == "" {
+		return nil, newOAuthError(OAuthErrorInvalidRequest, i18n.OAuthParameterRequired, "code_verifier")
+	}
+	codeHash := utility.HashToken(req.Code)
+	code, err := uc.codeRepo.FindByCodeHash(codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || code.OAuthClientID != client.ID {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, i18n.AuthorizationCodeInvalid)
+	}
+	if code.UsedAt != nil {
+		return nil, uc.revokeReusedCode(code)
+	}
+	now := time.Now()
+	if code.IsExpired(now) {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, i18n.AuthorizationCodeInvalid)
+	}
+	if code.RedirectURI != req.RedirectURI {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, i18n.RedirectURIMismatch)
+	}
+	if !codeVerifierPattern.MatchString(req.CodeVerifier) ||
+		subtle.ConstantTimeCompare([]byte(utility.PKCEChallenge(req.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, i18n.CodeVerifierInvalid)
+	}
+	familyID, err := utility.GenerateRandomToken(refreshTokenSize)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := uc.codeRepo.MarkUsed(code.ID, familyID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		// 同時に交換された場合は、先に交換したリクエストが記録したFamilyIDを取得し直して失効させます
+		code, err = uc.codeRepo.FindByCodeHash(codeHash)
+		if err != nil {
+			return nil, err
+		}
+		return nil, uc.revokeReusedCode(code)
+	}
+	user, err := uc.userRepo.FindByID(code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, i18n.AccountDisabled)
+	}
+	session := model.NewOAuthSession(
+		user.ID,
+		familyID,
+		client.ID,
+		model.SplitScopes(code.Scopes),
+		clientInfo.UserAgent,
+		clientInfo.IPAddress,
+	)
+	if err := uc.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return uc.authUseCase.issueTokens(user, session)
+}
+
+// refresh はクライアントに認可したセッションのリフレッシュトークンを使ってトークンを再発行します
+// ログインや他のクライアントのリフレッシュトークンは受け付けません
+func (uc *OAuthUseCase) refresh(req TokenRequest) (*TokenPair, error) {
+	client, err := uc.authenticateClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrorInvalidRequest, i18n.OAuthParameterRequired, "refresh_token")
+	}
+	tokens, err := uc.authUseCase.refresh(req.RefreshToken, &client.ID)
+	if err != nil {
+		var domainErr *domainerr.Error
+		if errors.As(err, &domainErr) && domainErr.Kind == domainerr.KindUnauthorized {
+			return nil, newOAuthError(OAuthErrorInvalidGrant, domainErr.Message, domainErr.Args...)
+		}
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// authenticateClient はクライアントIDと、機密クライアントの場合はクライアントシークレットを検証します
+func (uc *OAuthUseCase) authenticateClient(clientID, clientSecret string) (*model.OAuthClient, error) {
+	if clientID == "" {
+		return nil, newOAuthError(OAuthErrorInvalidClient, i18n.ClientAuthenticationFailed)
+	}
+	client, err := uc.clientRepo.FindByClientID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, newOAuthError(OAuthErrorInvalidClient, i18n.ClientAuthenticationFailed)
+	}
+	if client.IsConfidential() &&
+		subtle.ConstantTimeCompare([]byte(utility.HashToken(clientSecret)), []byte(client.ClientSecretHash)) != 1 {
+		return nil, newOAuthError(OAuthErrorInvalidClient, i18n.ClientAuthenticationFailed)
+	}
+
+	return client, nil
+}
+
+// revokeReusedCode は使用済みの認可コードが再び使われた際に、そのコードで発行したトークンを失効させ、返すエラーを作成します
+func (uc *OAuthUseCase) revokeReusedCode(code *model.OAuthAuthorizationCode) error {
+	if code != nil && code.FamilyID != "" {
+		if err := uc.authUseCase.revokeFamily(code.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	return newOAuthError(OAuthErrorInvalidGrant, i18n.AuthorizationCodeReused)
+}
+
+// isValidRedirectURI はリダイレクトURIとして登録できるかどうかを返します
+// フラグメントを含まない絶対URIで、httpsか、ループバックアドレスへのhttpのみを許可します
+func isValidRedirectURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" || parsed.Fragment != "" || strings.ContainsAny(uri, " #") {
+		return false
+	}
+	switch parsed.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := parsed.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return false
+	}
+}
+
+// withQuery はURIに既存のクエリパラメータを残したままパラメータを追加します
+func withQuery(uri string, params url.Values) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
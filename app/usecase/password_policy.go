@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+const (
+	// passwordMinLength はパスワードの最小文字数です
+	passwordMinLength = 8
+	// passwordMaxBytes はパスワードの最大バイト数です。bcryptは72バイトを超える部分を無視するため制限します
+	passwordMaxBytes = 72
+)
+
+// validatePassword はパスワードがポリシーを満たしているかを検証します
+// 8文字以上72バイト以内で、英字と数字をそれぞれ1文字以上含む必要があります
+func validatePassword(field string, password string) error {
+	if utf8.RuneCountInString(password) < passwordMinLength {
+		return domainerr.InvalidField(field, i18n.PasswordTooShort, passwordMinLength)
+	}
+	if len(password) > passwordMaxBytes {
+		return domainerr.InvalidField(field, i18n.PasswordTooLong, passwordMaxBytes)
+	}
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return domainerr.InvalidField(field, i18n.PasswordTooWeak)
+	}
+
+	return nil
+}
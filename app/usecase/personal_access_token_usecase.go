@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+const (
+	// accessTokenNameMaxLength は個人用アクセストークンの名前の最大文字数です
+	accessTokenNameMaxLength = 100
+	// accessTokenSize は個人用アクセストークンの乱数部分のバイト数です
+	accessTokenSize = 32
+	// accessTokenPrefixLength は一覧で見分けるために保存するトークンの先頭部分の文字数です
+	accessTokenPrefixLength = len(model.PersonalAccessTokenPrefix) + 8
+)
+
+// PersonalAccessTokenUseCase は個人用アクセストークン関連のビジネスロジックを提供します
+type PersonalAccessTokenUseCase struct {
+	accessTokenRepo repository.PersonalAccessTokenRepository
+	userRepo        repository.UserRepository
+}
+
+// NewPersonalAccessTokenUseCase は新しいPersonalAccessTokenUseCaseのインスタンスを作成します
+func NewPersonalAccessTokenUseCase(
+	accessTokenRepo repository.PersonalAccessTokenRepository,
+	userRepo repository.UserRepository,
+) *PersonalAccessTokenUseCase {
+	return &PersonalAccessTokenUseCase{
+		accessTokenRepo: accessTokenRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// CreatePersonalAccessTokenInput は個人用アクセストークンの作成内容です
+// ExpiresAtがnilの場合は無期限のトークンを作成します
+type CreatePersonalAccessTokenInput struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// GetTokens は指定されたユーザーの個人用アクセストークンを作成日時の新しい順に取得します
+func (uc *PersonalAccessTokenUseCase) GetTokens(userID uint) ([]*model.PersonalAccessToken, error) {
+	return uc.accessTokenRepo.FindByUserID(userID)
+}
+
+// CreateToken は新しい個人用アクセストークンを作成し、保存したトークンとトークン自体を返します
+// トークン自体は保存しないため、作成時にのみ返します
+func (uc *PersonalAccessTokenUseCase) CreateToken(
+	userID uint,
+	input CreatePersonalAccessTokenInput,
+) (*model.PersonalAccessToken, string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, "", domainerr.InvalidField("name", i18n.AccessTokenNameRequired)
+	}
+	if utf8.RuneCountInString(name) > accessTokenNameMaxLength {
+		return nil, "", domainerr.InvalidField("name", i18n.AccessTokenNameTooLong, accessTokenNameMaxLength)
+	}
+	scopes, err := model.ParseScopes(input.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkGrantableScopes(uc.userRepo, userID, scopes); err != nil {
+		return nil, "", err
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", domainerr.InvalidField("expires_at", i18n.ExpiryInPast)
+	}
+	secret, err := utility.GenerateRandomToken(accessTokenSize)
+	if err != nil {
+		return nil, "", err
+	}
+	plainToken := model.PersonalAccessTokenPrefix + secret
+	token := model.NewPersonalAccessToken(
+		userID,
+		name,
+		utility.HashToken(plainToken),
+		plainToken[:accessTokenPrefixLength],
+		scopes,
+		input.ExpiresAt,
+	)
+	if err := uc.accessTokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+
+	return token, plainToken, nil
+}
+
+// DeleteToken は個人用アクセストークンを削除し、以降は使用できなくします
+func (uc *PersonalAccessTokenUseCase) DeleteToken(id uint, currentUserID uint) error {
+	token, err := uc.accessTokenRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return domainerr.NotFound(i18n.AccessTokenNotFound)
+	}
+	if token.UserID != currentUserID {
+		return domainerr.Forbidden(i18n.AccessTokenForbidden)
+	}
+
+	return uc.accessTokenRepo.Delete(token.ID)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// projectNameMaxLength はプロジェクト名の最大文字数です
+const projectNameMaxLength = 64
+
+// ProjectUseCase はプロジェクト関連のビジネスロジックを提供します
+type ProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewProjectUseCase は新しいProjectUseCaseのインスタンスを作成します
+func NewProjectUseCase(projectRepo repository.ProjectRepository) *ProjectUseCase {
+	return &ProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// GetProjectsByUserID は指定されたユーザーのプロジェクトをTodo件数付きで取得します
+func (uc *ProjectUseCase) GetProjectsByUserID(userID uint, includeArchived bool) ([]*model.Project, error) {
+	projects, err := uc.projectRepo.FindByUserID(userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.countTodos(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		project.SetTodoCount(counts[project.ID])
+	}
+
+	return projects, nil
+}
+
+// GetProjectByID は指定されたIDのプロジェクトをTodo件数付きで取得します
+func (uc *ProjectUseCase) GetProjectByID(id uint, currentUserID uint) (*model.Project, error) {
+	project, err := uc.findOwnProject(id, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.countTodos(currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	project.SetTodoCount(counts[project.ID])
+
+	return project, nil
+}
+
+// CreateProject は新しいプロジェクトを作成します
+func (uc *ProjectUseCase) CreateProject(name string, userID uint) (*model.Project, error) {
+	name, err := uc.validateName(name, userID)
+	if err != nil {
+		return nil, err
+	}
+	project := model.NewProject(name, userID)
+	if err := uc.projectRepo.Create(project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// RenameProject はプロジェクトの名前を変更します
+func (uc *ProjectUseCase) RenameProject(id uint, name string, currentUserID uint) (*model.Project, error) {
+	project, err := uc.findOwnProject(id, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) != project.Name {
+		name, err = uc.validateName(name, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		project.Rename(name)
+	}
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return uc.GetProjectByID(id, currentUserID)
+}
+
+// ArchiveProject はプロジェクトのアーカイブ状態を変更します
+func (uc *ProjectUseCase) ArchiveProject(id uint, archived bool, currentUserID uint) (*model.Project, error) {
+	project, err := uc.findOwnProject(id, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	project.SetArchived(archived)
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return uc.GetProjectByID(id, currentUserID)
+}
+
+// DeleteProject はプロジェクトを削除します
+// 所属していたTodoは削除されず、プロジェクトに属さない状態になります
+func (uc *ProjectUseCase) DeleteProject(id uint, currentUserID uint) error {
+	if _, err := uc.findOwnProject(id, currentUserID); err != nil {
+		return err
+	}
+
+	return uc.projectRepo.Delete(id)
+}
+
+// findOwnProject は現在のユーザーが所有するプロジェクトを取得します
+func (uc *ProjectUseCase) findOwnProject(id uint, currentUserID uint) (*model.Project, error) {
+	project, err := uc.projectRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domainerr.NotFound(i18n.ProjectNotFound)
+	}
+	if project.UserID != currentUserID {
+		return nil, domainerr.Forbidden(i18n.ProjectForbidden)
+	}
+
+	return project, nil
+}
+
+// countTodos はプロジェクトIDをキーとしたTodo件数のマップを返します
+func (uc *ProjectUseCase) countTodos(userID uint) (map[uint]*model.ProjectTodoCount, error) {
+	counts, err := uc.projectRepo.CountTodosByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint]*model.ProjectTodoCount, len(counts))
+	for _, count := range counts {
+		result[count.ProjectID] = count
+	}
+
+	return result, nil
+}
+
+// validateName はプロジェクト名を検証し、前後の空白を取り除いた名前を返します
+func (uc *ProjectUseCase) validateName(name string, userID uint) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domainerr.InvalidField("name", i18n.ProjectNameRequired)
+	}
+	if utf8.RuneCountInString(name) > projectNameMaxLength {
+		return "", domainerr.InvalidField("name", i18n.ProjectNameTooLong, projectNameMaxLength)
+	}
+	existing, err := uc.projectRepo.FindByUserIDAndName(userID, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", domainerr.Conflict(i18n.ProjectNameTaken)
+	}
+
+	return name, nil
+}
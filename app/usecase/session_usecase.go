@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// SessionUseCase はログイン中のセッション関連のビジネスロジックを提供します
+type SessionUseCase struct {
+	sessionRepo      repository.SessionRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+// NewSessionUseCase は新しいSessionUseCaseのインスタンスを作成します
+func NewSessionUseCase(
+	sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+) *SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// GetSessions は指定されたユーザーのログイン中のセッションを最終利用日時の新しい順に取得します
+// リフレッシュトークンの有効期間を超えて使われていないセッションは含めません
+func (uc *SessionUseCase) GetSessions(userID uint) ([]*model.Session, error) {
+	return uc.sessionRepo.FindActiveByUserID(userID, time.Now().Add(-utility.RefreshTokenTTL()))
+}
+
+// RevokeSession は指定されたセッションを終了し、そのセッションのリフレッシュトークンを失効させます
+// セッションで発行済みのアクセストークンも以降は使用できなくなります
+func (uc *SessionUseCase) RevokeSession(id uint, currentUserID uint) error {
+	session, err := uc.sessionRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.IsRevoked() {
+		return domainerr.NotFound(i18n.SessionNotFound)
+	}
+	if session.UserID != currentUserID {
+		return domainerr.Forbidden(i18n.SessionForbidden)
+	}
+	if err := uc.sessionRepo.Revoke(session.ID); err != nil {
+		return err
+	}
+
+	return uc.refreshTokenRepo.RevokeFamily(session.FamilyID)
+}
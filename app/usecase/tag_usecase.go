@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// tagNameMaxLength はタグ名の最大文字数です
+const tagNameMaxLength = 32
+
+// TagUseCase はタグ関連のビジネスロジックを提供します
+type TagUseCase struct {
+	tagRepo  repository.TagRepository
+	todoRepo repository.TodoRepository
+}
+
+// NewTagUseCase は新しいTagUseCaseのインスタンスを作成します
+func NewTagUseCase(tagRepo repository.TagRepository, todoRepo repository.TodoRepository) *TagUseCase {
+	return &TagUseCase{
+		tagRepo:  tagRepo,
+		todoRepo: todoRepo,
+	}
+}
+
+// GetTagsByUserID は指定されたユーザーのタグを取得します
+func (uc *TagUseCase) GetTagsByUserID(userID uint) ([]*model.Tag, error) {
+	return uc.tagRepo.FindByUserID(userID)
+}
+
+// CreateTag は新しいタグを作成します
+func (uc *TagUseCase) CreateTag(name string, userID uint) (*model.Tag, error) {
+	name, err := uc.validateName(name, userID)
+	if err != nil {
+		return nil, err
+	}
+	tag := model.NewTag(name, userID)
+	if err := uc.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// RenameTag はタグの名前を変更します
+func (uc *TagUseCase) RenameTag(id uint, name string, currentUserID uint) (*model.Tag, error) {
+	tag, err := uc.findOwnTag(id, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) != tag.Name {
+		name, err = uc.validateName(name, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		tag.Rename(name)
+	}
+	if err := uc.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag はタグを削除します
+func (uc *TagUseCase) DeleteTag(id uint, currentUserID uint) error {
+	if _, err := uc.findOwnTag(id, currentUserID); err != nil {
+		return err
+	}
+
+	return uc.tagRepo.Delete(id)
+}
+
+// AttachTag はTodoにタグを付けます
+func (uc *TagUseCase) AttachTag(todoID uint, tagID uint, currentUserID uint) (*model.Todo, error) {
+	if err := uc.checkTodoAndTag(todoID, tagID, currentUserID); err != nil {
+		return nil, err
+	}
+	if err := uc.tagRepo.AttachToTodo(todoID, tagID); err != nil {
+		return nil, err
+	}
+
+	return uc.todoRepo.FindByID(todoID)
+}
+
+// DetachTag はTodoからタグを外します
+func (uc *TagUseCase) DetachTag(todoID uint, tagID uint, currentUserID uint) (*model.Todo, error) {
+	if err := uc.checkTodoAndTag(todoID, tagID, currentUserID); err != nil {
+		return nil, err
+	}
+	if err := uc.tagRepo.DetachFromTodo(todoID, tagID); err != nil {
+		return nil, err
+	}
+
+	return uc.todoRepo.FindByID(todoID)
+}
+
+// findOwnTag は現在のユーザーが所有するタグを取得します
+func (uc *TagUseCase) findOwnTag(id uint, currentUserID uint) (*model.Tag, error) {
+	tag, err := uc.tagRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, domainerr.NotFound(i18n.TagNotFound)
+	}
+	if tag.UserID != currentUserID {
+		return nil, domainerr.Forbidden(i18n.TagForbidden)
+	}
+
+	return tag, nil
+}
+
+// checkTodoAndTag はTodoとタグがどちらも現在のユーザーのものであることを確認します
+func (uc *TagUseCase) checkTodoAndTag(todoID uint, tagID uint, currentUserID uint) error {
+	todo, err := uc.todoRepo.FindByID(todoID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeTodo(todo, Actor{UserID: currentUserID}, todoWrite); err != nil {
+		return err
+	}
+	_, err = uc.findOwnTag(tagID, currentUserID)
+
+	return err
+}
+
+// validateName はタグ名を検証し、前後の空白を取り除いた名前を返します
+func (uc *TagUseCase) validateName(name string, userID uint) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domainerr.InvalidField("name", i18n.TagNameRequired)
+	}
+	if utf8.RuneCountInString(name) > tagNameMaxLength {
+		return "", domainerr.InvalidField("name", i18n.TagNameTooLong, tagNameMaxLength)
+	}
+	existing, err := uc.tagRepo.FindByUserIDAndName(userID, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", domainerr.Conflict(i18n.TagNameTaken)
+	}
+
+	return name, nil
+}
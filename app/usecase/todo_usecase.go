@@ -2,52 +2,218 @@ package usecase
 
 import (
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+	"github.com/jugeeem/golang-todo.git/app/utility/rrule"
 )
 
 // TodoUseCase はTodoアプリケーションユースケースを提供します
 type TodoUseCase struct {
-	todoRepo repository.TodoRepository
+	todoRepo     repository.TodoRepository
+	projectRepo  repository.ProjectRepository
+	tagRepo      repository.TagRepository
+	settingsRepo repository.UserSettingsRepository
+}
+
+// CreateTodoInput はTodo作成時の入力値です
+type CreateTodoInput struct {
+	Title       string
+	Description string
+	Priority    string
+	ProjectID   *uint
+	ParentID    *uint
+	StartAt     *time.Time
+	DueAt       *time.Time
+	Recurrence  string
+}
+
+// UpdateTodoInput はTodo更新時の入力値です
+// nilのフィールドは更新しません
+// ClearStartAtとClearDueAtがtrueの場合は開始日時と期限をそれぞれ削除します
+type UpdateTodoInput struct {
+	Title        string
+	Description  string
+	Completed    *bool
+	Priority     *string
+	StartAt      *time.Time
+	DueAt        *time.Time
+	ClearStartAt bool
+	ClearDueAt   bool
+	Recurrence   *string
+}
+
+// TodoListInput はTodo一覧取得時の条件です
+// Dueには"overdue"（期限切れの未完了タスク）、"today"（本日が期限）または"week"（今週が期限）を指定できます
+// DueWithinDaysが0より大きい場合は現在からN日以内が期限のタスクに絞り込みます
+// 本日と今週の範囲はユーザー設定のタイムゾーンと週の始まりに従います
+type TodoListInput struct {
+	Filter        repository.TodoFilter
+	Query         repository.ListQuery
+	Due           string
+	DueWithinDays int
+}
+
+// filter は期限の条件を反映した絞り込み条件を返します
+func (input TodoListInput) filter(now time.Time, settings *model.UserSettings) (repository.TodoFilter, error) {
+	filter := input.Filter
+	switch input.Due {
+	case "":
+	case "overdue":
+		completed := false
+		filter.Completed = &completed
+		filter.Due.To = &now
+	case "today":
+		startOfDay := settings.StartOfDay(now)
+		endOfDay := startOfDay.AddDate(0, 0, 1)
+		filter.Due = repository.TimeRange{From: &startOfDay, To: &endOfDay}
+	case "week":
+		startOfWeek := settings.StartOfWeek(now)
+		endOfWeek := startOfWeek.AddDate(0, 0, 7)
+		filter.Due = repository.TimeRange{From: &startOfWeek, To: &endOfWeek}
+	default:
+		return filter, domainerr.InvalidField("due", i18n.InvalidDue)
+	}
+	if input.DueWithinDays < 0 {
+		return filter, domainerr.InvalidField("due_within", i18n.InvalidDueWithin)
+	}
+	if input.DueWithinDays > 0 {
+		until := now.AddDate(0, 0, input.DueWithinDays)
+		filter.Due = repository.TimeRange{From: &now, To: &until}
+	}
+
+	return filter, nil
+}
+
+// query はユーザー設定の既定の並び順を反映したページングの条件を返します
+func (input TodoListInput) query(settings *model.UserSettings) repository.ListQuery {
+	query := input.Query
+	if query.Sort == "" {
+		query.Sort = settings.DefaultSort
+	}
+
+	return query
 }
 
 // NewTodoUseCase は新しいTodoUseCaseのインスタンスを作成します
-func NewTodoUseCase(todoRepo repository.TodoRepository) *TodoUseCase {
+func NewTodoUseCase(
+	todoRepo repository.TodoRepository,
+	projectRepo repository.ProjectRepository,
+	tagRepo repository.TagRepository,
+	settingsRepo repository.UserSettingsRepository,
+) *TodoUseCase {
 	return &TodoUseCase{
-		todoRepo: todoRepo,
+		todoRepo:     todoRepo,
+		projectRepo:  projectRepo,
+		tagRepo:      tagRepo,
+		settingsRepo: settingsRepo,
 	}
 }
 
-// GetAllTodos は全てのTodoタスクを取得します
-func (uc *TodoUseCase) GetAllTodos() ([]*model.Todo, error) {
-	return uc.todoRepo.FindAll()
+// GetAllTodos は全てのTodoタスクを条件に従ってページ単位で取得します
+// 期限による絞り込みと既定の並び順には操作を行うユーザーの設定を使います
+func (uc *TodoUseCase) GetAllTodos(input TodoListInput, currentUserID uint) (*repository.TodoPage, error) {
+	settings, err := findUserSettings(uc.settingsRepo, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := input.filter(time.Now(), settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.todoRepo.FindPage(filter, input.query(settings))
 }
 
-// GetTodoByID は指定されたIDのTodoタスクを取得します
-func (uc *TodoUseCase) GetTodoByID(id uint) (*model.Todo, error) {
-	return uc.todoRepo.FindByID(id)
+// GetTodoByID は指定されたIDのTodoタスクをサブタスクを含めて取得します
+func (uc *TodoUseCase) GetTodoByID(id uint, actor Actor) (*model.Todo, error) {
+	todo, err := uc.findTodo(id, actor, todoRead)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.loadChildren(todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
 }
 
-// GetTodosByUserID は指定されたユーザーIDのTodoタスクを取得します
-func (uc *TodoUseCase) GetTodosByUserID(userID uint) ([]*model.Todo, error) {
-	return uc.todoRepo.FindByUserID(userID)
+// GetTodosByUserID は指定されたユーザーIDのTodoタスクを条件に従ってページ単位で取得します
+func (uc *TodoUseCase) GetTodosByUserID(userID uint, input TodoListInput) (*repository.TodoPage, error) {
+	settings, err := findUserSettings(uc.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := input.filter(time.Now(), settings)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = &userID
+
+	return uc.todoRepo.FindPage(filter, input.query(settings))
 }
 
 // CreateTodo は新しいTodoタスクを作成します
-func (uc *TodoUseCase) CreateTodo(
-	title string,
-	description string,
-	userID uint,
-) (*model.Todo, error) {
-	if title == "" {
-		return nil, errors.New("タイトルは必須です")
+// 親タスクを指定する場合は、その親タスクを編集する権限が必要です
+// プロジェクトも親タスクも指定しない場合は、ユーザー設定の既定のプロジェクトに追加します
+func (uc *TodoUseCase) CreateTodo(input CreateTodoInput, userID uint) (*model.Todo, error) {
+	if input.Title == "" {
+		return nil, domainerr.InvalidField("title", i18n.TodoTitleRequired)
 	}
 	if userID == 0 {
-		return nil, errors.New("ユーザーIDは必須です")
+		return nil, domainerr.Validation(i18n.TodoUserIDRequired)
+	}
+	priority := model.PriorityNone
+	if input.Priority != "" {
+		var err error
+		priority, err = parsePriority(input.Priority)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := validateSchedule(input.StartAt, input.DueAt); err != nil {
+		return nil, err
 	}
-	todo := model.NewTodo(title, description, userID)
-	err := uc.todoRepo.Create(todo)
+	recurrence, err := normalizeRecurrence(input.Recurrence, input.DueAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkProject(input.ProjectID, userID); err != nil {
+		return nil, err
+	}
+	projectID := input.ProjectID
+	if projectID == nil && input.ParentID == nil {
+		projectID, err = uc.defaultProjectID(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.ParentID != nil {
+		parent, err := uc.todoRepo.FindByID(*input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if authorizeTodo(parent, Actor{UserID: userID}, todoWrite) != nil {
+			return nil, domainerr.InvalidField("parent_id", i18n.ParentTodoNotFound)
+		}
+		if parent.Completed {
+			return nil, domainerr.Conflict(i18n.ParentTodoCompleted)
+		}
+		if projectID == nil {
+			projectID = parent.ProjectID
+		}
+	}
+	todo := model.NewTodo(input.Title, input.Description, userID)
+	todo.UpdatePriority(priority)
+	todo.MoveToProject(projectID)
+	todo.ParentID = input.ParentID
+	todo.UpdateSchedule(input.StartAt, input.DueAt)
+	todo.SetRecurrence(recurrence, input.DueAt)
+	err = uc.todoRepo.Create(todo)
 	if err != nil {
 		return nil, err
 	}
@@ -58,43 +224,386 @@ func (uc *TodoUseCase) CreateTodo(
 // UpdateTodo は既存のTodoタスクを更新します
 func (uc *TodoUseCase) UpdateTodo(
 	id uint,
-	title string,
-	description string,
-	completed *bool,
-	currentUserID uint,
+	input UpdateTodoInput,
+	actor Actor,
 ) (*model.Todo, error) {
-	todo, err := uc.todoRepo.FindByID(id)
+	todo, err := uc.findTodo(id, actor, todoWrite)
 	if err != nil {
 		return nil, err
 	}
-	if todo.UserID != currentUserID {
-		return nil, errors.New("このTodoを編集する権限がありません")
+	if input.Title != "" {
+		todo.UpdateTitle(input.Title, input.Description)
+	}
+	if input.StartAt != nil || input.DueAt != nil || input.ClearStartAt || input.ClearDueAt {
+		startAt, dueAt := todo.StartAt, todo.DueAt
+		if input.ClearStartAt {
+			startAt = nil
+		} else if input.StartAt != nil {
+			startAt = input.StartAt
+		}
+		if input.ClearDueAt {
+			dueAt = nil
+		} else if input.DueAt != nil {
+			dueAt = input.DueAt
+		}
+		if err := validateSchedule(startAt, dueAt); err != nil {
+			return nil, err
+		}
+		todo.UpdateSchedule(startAt, dueAt)
+	}
+	if input.Priority != nil {
+		priority, err := parsePriority(*input.Priority)
+		if err != nil {
+			return nil, err
+		}
+		todo.UpdatePriority(priority)
+	}
+	if input.Recurrence != nil {
+		recurrence, err := normalizeRecurrence(*input.Recurrence, todo.DueAt)
+		if err != nil {
+			return nil, err
+		}
+		todo.SetRecurrence(recurrence, todo.DueAt)
 	}
-	if title != "" {
-		todo.UpdateTitle(title, description)
+	// 繰り返しは期限を基準にするため、繰り返しを外さずに期限だけを削除することはできません
+	if todo.Recurrence != "" && todo.DueAt == nil {
+		return nil, domainerr.InvalidField("due_at", i18n.RecurrenceRequiresDue)
 	}
-	if completed != nil {
-		if *completed != todo.Completed {
-			todo.ToggleCompleted()
+	reopened := false
+	if input.Completed != nil && *input.Completed != todo.Completed {
+		if *input.Completed {
+			if err := uc.loadChildren(todo); err != nil {
+				return nil, err
+			}
+			if todo.HasOpenChildren() {
+				return nil, domainerr.Conflict(i18n.OpenSubtasksRemain)
+			}
+		}
+		todo.ToggleCompleted()
+		reopened = !todo.Completed
+		if todo.Completed && todo.Recurrence != "" {
+			if err := uc.createNextOccurrence(todo); err != nil {
+				return nil, err
+			}
+			// 繰り返しは次のTodoに引き継がれるため、完了したTodoからは外します
+			todo.SetRecurrence("", nil)
 		}
 	}
 	err = uc.todoRepo.Update(todo)
 	if err != nil {
 		return nil, err
 	}
+	if reopened {
+		if err := uc.reopenAncestors(todo); err != nil {
+			return nil, err
+		}
+	}
 
 	return todo, nil
 }
 
-// DeleteTodo は指定されたIDのTodoタスクを削除します
-func (uc *TodoUseCase) DeleteTodo(id uint, currentUserID uint) error {
+// MoveTodo はTodoタスクを別のプロジェクトに移動します
+// projectIDにnilを指定するとプロジェクトから外します
+func (uc *TodoUseCase) MoveTodo(id uint, projectID *uint, actor Actor) (*model.Todo, error) {
+	todo, err := uc.findTodo(id, actor, todoWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkProject(projectID, todo.UserID); err != nil {
+		return nil, err
+	}
+	todo.MoveToProject(projectID)
+	if err := uc.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// DeleteTodo は指定されたIDのTodoタスクをサブタスクと共にゴミ箱に移動します
+// permanentがtrueの場合はゴミ箱を経由せずに完全に削除します（ゴミ箱にあるTodoも対象です）
+func (uc *TodoUseCase) DeleteTodo(id uint, actor Actor, permanent bool) error {
 	todo, err := uc.todoRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
-	if todo.UserID != currentUserID {
-		return errors.New("このTodoを削除する権限がありません")
+	if todo == nil && permanent {
+		todo, err = uc.todoRepo.FindTrashedByID(id)
+		if err != nil {
+			return err
+		}
+	}
+	if err := authorizeTodo(todo, actor, todoWrite); err != nil {
+		return err
+	}
+	if permanent {
+		return uc.todoRepo.HardDelete(id)
 	}
 
 	return uc.todoRepo.Delete(id)
 }
+
+// SearchTodos は指定されたユーザーのTodoタスクをタイトルと説明で全文検索し、関連度の高い順に取得します
+func (uc *TodoUseCase) SearchTodos(userID uint, text string, limit int) ([]*model.TodoSearchResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, domainerr.InvalidField("q", i18n.SearchQueryRequired)
+	}
+
+	return uc.todoRepo.Search(userID, text, limit)
+}
+
+// GetTrashedTodos は指定されたユーザーのゴミ箱にあるTodoタスクを取得します
+func (uc *TodoUseCase) GetTrashedTodos(userID uint) ([]*model.Todo, error) {
+	return uc.todoRepo.FindTrashedByUserID(userID)
+}
+
+// RestoreTodo はゴミ箱にあるTodoタスクをサブタスクと共に復元します
+func (uc *TodoUseCase) RestoreTodo(id uint, actor Actor) (*model.Todo, error) {
+	todo, err := uc.todoRepo.FindTrashedByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTodo(todo, actor, todoWrite); err != nil {
+		return nil, err
+	}
+	if todo.ParentID != nil {
+		parent, err := uc.todoRepo.FindByID(*todo.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, domainerr.Conflict(i18n.ParentTodoTrashed)
+		}
+	}
+	if err := uc.todoRepo.Restore(id); err != nil {
+		return nil, err
+	}
+	if !todo.Completed {
+		if err := uc.reopenAncestors(todo); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetTodoByID(id, actor)
+}
+
+// PurgeTrash はゴミ箱に移動してから保持期間を過ぎたTodoタスクを完全に削除し、削除件数を返します
+func (uc *TodoUseCase) PurgeTrash(retention time.Duration) (int64, error) {
+	return uc.todoRepo.PurgeTrashedBefore(time.Now().Add(-retention))
+}
+
+// createNextOccurrence は繰り返しルールに従って次回のTodoを作成します
+// 次回の期限は基準日時から所有者のタイムゾーンで計算し、開始日時は期限との間隔を保ったまま移動します
+// タイトル・説明・優先度・プロジェクト・親タスク・タグは完了したTodoから引き継ぎます
+func (uc *TodoUseCase) createNextOccurrence(todo *model.Todo) error {
+	if todo.DueAt == nil || todo.RecurrenceStart == nil {
+		return nil
+	}
+	rule, err := rrule.Parse(todo.Recurrence)
+	if err != nil {
+		return err
+	}
+	// BYDAYやBYMONTHDAYの曜日と日付はサーバーではなく所有者のタイムゾーンで判定します
+	settings, err := findUserSettings(uc.settingsRepo, todo.UserID)
+	if err != nil {
+		return err
+	}
+	nextDueAt, ok := rule.Next(todo.RecurrenceStart.In(settings.Location()), *todo.DueAt)
+	if !ok {
+		return nil
+	}
+	var nextStartAt *time.Time
+	if todo.StartAt != nil {
+		startAt := nextDueAt.Add(-todo.DueAt.Sub(*todo.StartAt))
+		nextStartAt = &startAt
+	}
+	next := model.NewTodo(todo.Title, todo.Description, todo.UserID)
+	next.UpdatePriority(todo.Priority)
+	next.MoveToProject(todo.ProjectID)
+	next.ParentID = todo.ParentID
+	next.UpdateSchedule(nextStartAt, &nextDueAt)
+	next.SetRecurrence(todo.Recurrence, todo.RecurrenceStart)
+	if err := uc.todoRepo.Create(next); err != nil {
+		return err
+	}
+	for _, tag := range todo.Tags {
+		if err := uc.tagRepo.AttachToTodo(next.ID, tag.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findTodo はゴミ箱にないTodoを取得し、指定された操作の権限を確認します
+func (uc *TodoUseCase) findTodo(id uint, actor Actor, action todoAction) (*model.Todo, error) {
+	todo, err := uc.todoRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTodo(todo, actor, action); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// loadChildren はTodoのサブタスクを再帰的に読み込みます
+func (uc *TodoUseCase) loadChildren(todo *model.Todo) error {
+	children, err := uc.todoRepo.FindByParentID(todo.ID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := uc.loadChildren(child); err != nil {
+			return err
+		}
+	}
+	if children == nil {
+		children = []*model.Todo{}
+	}
+	todo.Children = children
+
+	return nil
+}
+
+// reopenAncestors は未完了に戻されたサブタスクの親タスクを未完了に戻します
+// 親タスクは未完了のサブタスクを持ったまま完了にできないためです
+func (uc *TodoUseCase) reopenAncestors(todo *model.Todo) error {
+	parentID := todo.ParentID
+	for parentID != nil {
+		parent, err := uc.todoRepo.FindByID(*parentID)
+		if err != nil {
+			return err
+		}
+		if parent == nil || !parent.Completed {
+			return nil
+		}
+		parent.ToggleCompleted()
+		if err := uc.todoRepo.Update(parent); err != nil {
+			return err
+		}
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
+
+// defaultProjectID はユーザー設定の既定のプロジェクトを返します
+// 設定されていない場合や、プロジェクトがアーカイブされている場合はnilを返します
+func (uc *TodoUseCase) defaultProjectID(userID uint) (*uint, error) {
+	settings, err := findUserSettings(uc.settingsRepo, userID)
+	if err != nil || settings.DefaultProjectID == nil {
+		return nil, err
+	}
+	project, err := uc.projectRepo.FindByID(*settings.DefaultProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil || project.UserID != userID || project.Archived {
+		return nil, nil
+	}
+
+	return &project.ID, nil
+}
+
+// checkProject はTodoの移動先プロジェクトが現在のユーザーのアクティブなプロジェクトであることを確認します
+func (uc *TodoUseCase) checkProject(projectID *uint, currentUserID uint) error {
+	if projectID == nil {
+		return nil
+	}
+	project, err := uc.projectRepo.FindByID(*projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil || project.UserID != currentUserID {
+		return domainerr.InvalidField("project_id", i18n.ProjectNotFound)
+	}
+	if project.Archived {
+		return domainerr.Conflict(i18n.ProjectArchived)
+	}
+
+	return nil
+}
+
+// validateSchedule は開始日時が期限より後になっていないかを検証します
+func validateSchedule(startAt *time.Time, dueAt *time.Time) error {
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		return domainerr.InvalidField("start_at", i18n.StartAfterDue)
+	}
+
+	return nil
+}
+
+// normalizeRecurrence は繰り返しルールを検証し、正規化したRRULE文字列を返します
+// 空のルールは繰り返しなしとして扱います
+func normalizeRecurrence(value string, dueAt *time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if dueAt == nil {
+		return "", domainerr.InvalidField("due_at", i18n.RecurrenceRequiresDue)
+	}
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return "", recurrenceError(err)
+	}
+
+	return rule.String(), nil
+}
+
+// recurrenceMessage は繰り返しルールを解析できなかった理由に対応するメッセージです
+// withValueがtrueの場合は、原因となった値をメッセージに埋め込みます
+type recurrenceMessage struct {
+	id        i18n.MessageID
+	withValue bool
+}
+
+// recurrenceMessages は繰り返しルールを解析できなかった理由とメッセージの対応です
+var recurrenceMessages = map[rrule.ErrorReason]recurrenceMessage{
+	rrule.ReasonEmpty:                  {i18n.RecurrenceEmpty, false},
+	rrule.ReasonMalformed:              {i18n.RecurrenceMalformed, true},
+	rrule.ReasonDuplicatePart:          {i18n.RecurrenceDuplicatePart, true},
+	rrule.ReasonUnsupportedPart:        {i18n.RecurrenceUnsupportedPart, true},
+	rrule.ReasonFreqRequired:           {i18n.RecurrenceFreqRequired, false},
+	rrule.ReasonUnsupportedFreq:        {i18n.RecurrenceUnsupportedFreq, true},
+	rrule.ReasonNotPositive:            {i18n.RecurrenceNotPositive, true},
+	rrule.ReasonInvalidUntil:           {i18n.RecurrenceInvalidUntil, true},
+	rrule.ReasonInvalidByDay:           {i18n.RecurrenceInvalidByDay, true},
+	rrule.ReasonInvalidOrdinal:         {i18n.RecurrenceInvalidOrdinal, true},
+	rrule.ReasonInvalidMonthDay:        {i18n.RecurrenceInvalidMonthDay, true},
+	rrule.ReasonInvalidWeekday:         {i18n.RecurrenceInvalidWeekday, true},
+	rrule.ReasonCountWithUntil:         {i18n.RecurrenceCountWithUntil, false},
+	rrule.ReasonOrdinalRequiresMonthly: {i18n.RecurrenceOrdinalRequiresMonthly, true},
+	rrule.ReasonYearlyByDay:            {i18n.RecurrenceYearlyByDay, false},
+	rrule.ReasonNeverMatches:           {i18n.RecurrenceNeverMatches, false},
+}
+
+// recurrenceError は繰り返しルールを解析できなかったエラーを、理由に対応するメッセージの入力値エラーに変換します
+// 理由を特定できない場合は汎用のメッセージにします
+func recurrenceError(err error) error {
+	var parseErr *rrule.ParseError
+	if !errors.As(err, &parseErr) {
+		return domainerr.InvalidField("recurrence", i18n.InvalidRecurrence)
+	}
+	message, ok := recurrenceMessages[parseErr.Reason]
+	if !ok {
+		return domainerr.InvalidField("recurrence", i18n.InvalidRecurrence)
+	}
+	if message.withValue {
+		return domainerr.InvalidField("recurrence", message.id, parseErr.Value)
+	}
+
+	return domainerr.InvalidField("recurrence", message.id)
+}
+
+// parsePriority は優先度の文字列を検証して変換します
+func parsePriority(name string) (model.Priority, error) {
+	priority, err := model.ParsePriority(name)
+	if err != nil {
+		return model.PriorityNone, domainerr.InvalidField("priority", i18n.InvalidPriority)
+	}
+
+	return priority, nil
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+func TestCreateNextOccurrenceUsesOwnerTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		timezone string
+		rule     string
+		start    time.Time
+		want     time.Time
+	}{
+		{
+			// 2024-01-01 00:30 JSTはUTCでは日曜日のため、サーバーのタイムゾーンで判定すると月曜日を誤って判定します
+			name:     "BYDAYは所有者のタイムゾーンの曜日で判定する",
+			timezone: "Asia/Tokyo",
+			rule:     "FREQ=WEEKLY;BYDAY=MO",
+			start:    time.Date(2024, 1, 1, 0, 30, 0, 0, tokyo),
+			want:     time.Date(2024, 1, 8, 0, 30, 0, 0, tokyo),
+		},
+		{
+			name:     "BYMONTHDAYは所有者のタイムゾーンの日付で判定する",
+			timezone: "Asia/Tokyo",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=1",
+			start:    time.Date(2024, 1, 1, 8, 0, 0, 0, tokyo),
+			want:     time.Date(2024, 2, 1, 8, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "設定がない場合はUTCで判定する",
+			timezone: "",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:    time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todoRepo := &fakeTodoRepository{}
+			settingsRepo := &fakeUserSettingsRepository{settings: map[uint]*model.UserSettings{}}
+			if tt.timezone != "" {
+				settings := model.NewUserSettings(1)
+				settings.Timezone = tt.timezone
+				settingsRepo.settings[1] = settings
+			}
+			uc := NewTodoUseCase(todoRepo, nil, nil, settingsRepo)
+			start := tt.start.UTC()
+			todo := model.NewTodo("chore", "", 1)
+			todo.UpdateSchedule(nil, &start)
+			todo.SetRecurrence(tt.rule, &start)
+
+			if err := uc.createNextOccurrence(todo); err != nil {
+				t.Fatalf("createNextOccurrence: %v", err)
+			}
+			if len(todoRepo.created) != 1 {
+				t.Fatalf("created %d todos, want 1", len(todoRepo.created))
+			}
+			if got := *todoRepo.created[0].DueAt; !got.Equal(tt.want) {
+				t.Errorf("next due = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecurrenceLocalizesParseErrors(t *testing.T) {
+	dueAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rule string
+		ja   string
+		en   string
+	}{
+		{
+			name: "値を含む理由は値をメッセージに埋め込む",
+			rule: "FREQ=HOURLY",
+			ja:   "FREQにはDAILY、WEEKLY、MONTHLYまたはYEARLYを指定してください: HOURLY",
+			en:   "FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY: HOURLY",
+		},
+		{
+			name: "空の要素も値として埋め込む",
+			rule: "FREQ=DAILY;",
+			ja:   "繰り返しルールの形式が不正です: ",
+			en:   "The recurrence rule is malformed: ",
+		},
+		{
+			name: "値を含まない理由",
+			rule: "FREQ=DAILY;COUNT=2;UNTIL=20240101",
+			ja:   "COUNTとUNTILは同時に指定できません",
+			en:   "COUNT and UNTIL cannot be used together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := normalizeRecurrence(tt.rule, &dueAt)
+			var domainErr *domainerr.Error
+			if !errors.As(err, &domainErr) {
+				t.Fatalf("normalizeRecurrence() error = %v, want *domainerr.Error", err)
+			}
+			if domainErr.Kind != domainerr.KindValidation {
+				t.Errorf("Kind = %d, want KindValidation", domainErr.Kind)
+			}
+			if got := domainErr.Localize(i18n.Japanese); got != tt.ja {
+				t.Errorf("Localize(ja) = %q, want %q", got, tt.ja)
+			}
+			if got := domainErr.Localize(i18n.English); got != tt.en {
+				t.Errorf("Localize(en) = %q, want %q", got, tt.en)
+			}
+			if len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "recurrence" {
+				t.Errorf("Fields = %+v, want one recurrence field", domainErr.Fields)
+			}
+		})
+	}
+}
+
+func TestUpdateTodoClearsSchedule(t *testing.T) {
+	startAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	empty := ""
+	tests := []struct {
+		name       string
+		recurrence string
+		input      UpdateTodoInput
+		wantStart  bool
+		wantDue    bool
+		wantErr    bool
+	}{
+		{
+			name:      "clear_due_atで期限だけを削除する",
+			input:     UpdateTodoInput{ClearDueAt: true},
+			wantStart: true,
+		},
+		{
+			name:    "clear_start_atで開始日時だけを削除する",
+			input:   UpdateTodoInput{ClearStartAt: true},
+			wantDue: true,
+		},
+		{
+			name:      "フィールドを省略した場合は日時を維持する",
+			input:     UpdateTodoInput{Title: "renamed"},
+			wantStart: true,
+			wantDue:   true,
+		},
+		{
+			name:       "繰り返しのあるTodoの期限は削除できない",
+			recurrence: "FREQ=DAILY",
+			input:      UpdateTodoInput{ClearDueAt: true},
+			wantErr:    true,
+		},
+		{
+			name:       "繰り返しも外す場合は期限を削除できる",
+			recurrence: "FREQ=DAILY",
+			input:      UpdateTodoInput{ClearDueAt: true, Recurrence: &empty},
+			wantStart:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := model.NewTodo("chore", "", 1)
+			todo.ID = 1
+			start, due := startAt, dueAt
+			todo.UpdateSchedule(&start, &due)
+			if tt.recurrence != "" {
+				todo.SetRecurrence(tt.recurrence, &due)
+			}
+			todoRepo := &fakeTodoRepository{todos: map[uint]*model.Todo{1: todo}}
+			uc := NewTodoUseCase(todoRepo, nil, nil, nil)
+
+			got, err := uc.UpdateTodo(1, tt.input, Actor{UserID: 1, Role: model.RoleUser})
+			if tt.wantErr {
+				var domainErr *domainerr.Error
+				if !errors.As(err, &domainErr) || domainErr.Message != i18n.RecurrenceRequiresDue {
+					t.Fatalf("err = %v, want %s", err, i18n.RecurrenceRequiresDue)
+				}
+				if todoRepo.updated != 0 {
+					t.Error("todo was saved despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateTodo: %v", err)
+			}
+			if (got.StartAt != nil) != tt.wantStart {
+				t.Errorf("StartAt = %v, want set=%t", got.StartAt, tt.wantStart)
+			}
+			if (got.DueAt != nil) != tt.wantDue {
+				t.Errorf("DueAt = %v, want set=%t", got.DueAt, tt.wantDue)
+			}
+			if got.Recurrence != "" && got.DueAt == nil {
+				t.Error("recurring todo has no due date")
+			}
+		})
+	}
+}
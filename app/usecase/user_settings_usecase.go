@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
+	"github.com/jugeeem/golang-todo.git/app/domain/model"
+	"github.com/jugeeem/golang-todo.git/app/domain/repository"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
+)
+
+// UserSettingsUseCase はユーザー設定のビジネスロジックを提供します
+type UserSettingsUseCase struct {
+	settingsRepo repository.UserSettingsRepository
+	projectRepo  repository.ProjectRepository
+}
+
+// UpdateUserSettingsInput はユーザー設定の更新時の入力値です
+// 設定全体を置き換えるため、空の項目は既定値に戻ります
+type UpdateUserSettingsInput struct {
+	Timezone         string
+	Locale           string
+	DefaultSort      string
+	WeekStart        string
+	DefaultProjectID *uint
+}
+
+// NewUserSettingsUseCase は新しいUserSettingsUseCaseのインスタンスを作成します
+func NewUserSettingsUseCase(
+	settingsRepo repository.UserSettingsRepository,
+	projectRepo repository.ProjectRepository,
+) *UserSettingsUseCase {
+	return &UserSettingsUseCase{
+		settingsRepo: settingsRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+// GetSettings は指定されたユーザーの設定を取得します。保存されていない場合は既定値を返します
+func (uc *UserSettingsUseCase) GetSettings(userID uint) (*model.UserSettings, error) {
+	return findUserSettings(uc.settingsRepo, userID)
+}
+
+// UpdateSettings は指定されたユーザーの設定を置き換えます
+func (uc *UserSettingsUseCase) UpdateSettings(userID uint, input UpdateUserSettingsInput) (*model.UserSettings, error) {
+	settings, err := findUserSettings(uc.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	defaults := model.NewUserSettings(userID)
+	settings.Timezone = defaults.Timezone
+	if input.Timezone != "" {
+		if _, err := time.LoadLocation(input.Timezone); err != nil {
+			return nil, domainerr.InvalidField("timezone", i18n.InvalidTimezone, input.Timezone)
+		}
+		settings.Timezone = input.Timezone
+	}
+	if input.Locale != "" {
+		if _, ok := i18n.ParseLanguage(input.Locale); !ok {
+			return nil, domainerr.InvalidField("locale", i18n.InvalidLocale)
+		}
+	}
+	settings.Locale = input.Locale
+	if input.DefaultSort != "" && !repository.IsTodoSort(input.DefaultSort) {
+		return nil, domainerr.InvalidField("default_sort", i18n.InvalidSort)
+	}
+	settings.DefaultSort = input.DefaultSort
+	settings.WeekStart = defaults.WeekStart
+	if input.WeekStart != "" {
+		weekStart, ok := model.ParseWeekday(input.WeekStart)
+		if !ok {
+			return nil, domainerr.InvalidField("week_start", i18n.InvalidWeekStart)
+		}
+		settings.WeekStart = weekStart
+	}
+	if input.DefaultProjectID != nil {
+		project, err := uc.projectRepo.FindByID(*input.DefaultProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil || project.UserID != userID {
+			return nil, domainerr.InvalidField("default_project_id", i18n.ProjectNotFound)
+		}
+		if project.Archived {
+			return nil, domainerr.Conflict(i18n.ProjectArchived)
+		}
+	}
+	settings.DefaultProjectID = input.DefaultProjectID
+	if err := uc.settingsRepo.Save(settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// findUserSettings は指定されたユーザーの設定を取得します。保存されていない場合は既定値を返します
+func findUserSettings(settingsRepo repository.UserSettingsRepository, userID uint) (*model.UserSettings, error) {
+	settings, err := settingsRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return model.NewUserSettings(userID), nil
+	}
+
+	return settings, nil
+}
@@ -1,35 +1,47 @@
 package usecase
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
+	"github.com/jugeeem/golang-todo.git/app/domain/domainerr"
 	"github.com/jugeeem/golang-todo.git/app/domain/model"
 	"github.com/jugeeem/golang-todo.git/app/domain/repository"
 	"github.com/jugeeem/golang-todo.git/app/utility"
+	"github.com/jugeeem/golang-todo.git/app/utility/i18n"
 )
 
 // UserUseCase はユーザーアプリケーションユースケースを提供します
 type UserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo    repository.UserRepository
+	authUseCase *AuthUseCase
 }
 
 // NewUserUseCase はUserUseCaseの新しいインスタンスを作成します
-func NewUserUseCase(userRepo repository.UserRepository) *UserUseCase {
+// ユーザーの無効化時に発行済みのトークンとセッションを失効させるため、AuthUseCaseを使用します
+func NewUserUseCase(userRepo repository.UserRepository, authUseCase *AuthUseCase) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		authUseCase: authUseCase,
 	}
 }
 
-// GetAllUsers は全てのユーザーを取得します
-func (uc *UserUseCase) GetAllUsers() ([]*model.User, error) {
-	return uc.userRepo.FindAll()
+// GetAllUsers は有効なユーザーを条件に従ってページ単位で取得します
+func (uc *UserUseCase) GetAllUsers(filter repository.UserFilter, query repository.ListQuery) (*repository.UserPage, error) {
+	return uc.userRepo.FindPage(filter, query)
 }
 
 // GetUserByID は指定されたIDのユーザーを取得します
 func (uc *UserUseCase) GetUserByID(id uint) (*model.User, error) {
-	return uc.userRepo.FindByID(id)
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.NotFound(i18n.UserNotFound)
+	}
+
+	return user, nil
 }
 
 // GetUserByUsername はユーザー名でユーザーを検索します
@@ -49,7 +61,7 @@ func (uc *UserUseCase) GetUserByUsernameAndPassword(username, password string) (
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.UserNotFound)
 	}
 
 	return user, nil
@@ -62,7 +74,7 @@ func (uc *UserUseCase) GetUserByEmailAndPassword(email, password string) (*model
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.UserNotFound)
 	}
 
 	return user, nil
@@ -75,7 +87,7 @@ func (uc *UserUseCase) GetUserByUsernameOrEmail(username, email string) (*model.
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.UserNotFound)
 	}
 
 	return user, nil
@@ -88,7 +100,7 @@ func (uc *UserUseCase) GetUserByUsernameAndEmail(username, email string) (*model
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.UserNotFound)
 	}
 
 	return user, nil
@@ -101,33 +113,41 @@ func (uc *UserUseCase) CreateUser(username, password, email string) (*model.User
 		fmt.Printf("CreateUser took %v\n", time.Since(start))
 	}()
 	if username == "" || password == "" || email == "" {
-		return nil, errors.New("ユーザー名、パスワード、メールアドレスは必須です")
+		return nil, domainerr.Validation(i18n.UserFieldsRequired)
 	}
-	hashedPassword, err := utility.HashPassword(password)
+	if err := validatePassword("password", password); err != nil {
+		return nil, err
+	}
+	existingUser, err := uc.userRepo.FindByUsernameOrEmailWithDeactivated(username, email)
 	if err != nil {
 		return nil, err
 	}
-	user := model.NewUser(username, hashedPassword, email)
+	if existingUser != nil {
+		return nil, domainerr.Conflict(i18n.UserAlreadyExists)
+	}
+	hashedPassword, pepperID, err := utility.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user := model.NewUser(username, hashedPassword, pepperID, email)
 
 	return uc.userRepo.Create(user)
 }
 
-// UpdateUser は既存のユーザーを更新します
-func (uc *UserUseCase) UpdateUser(id uint, username, password, email string) (*model.User, error) {
+// UpdateUser は既存のユーザーのユーザー名とメールアドレスを更新します
+// パスワードはAuthUseCase.ChangePasswordで変更します
+func (uc *UserUseCase) UpdateUser(id uint, username, email string) (*model.User, error) {
 	user, err := uc.userRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 	if user == nil {
-		return nil, errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.UserNotFound)
 	}
 
 	if username != "" {
 		user.Username = username
 	}
-	if password != "" {
-		user.Password = password
-	}
 	if email != "" {
 		user.Email = email
 	}
@@ -135,34 +155,80 @@ func (uc *UserUseCase) UpdateUser(id uint, username, password, email string) (*m
 	return uc.userRepo.Update(user)
 }
 
-// RemoveUser は指定されたIDのユーザーを削除します
-func (uc *UserUseCase) RemoveUser(id uint) error {
+// ChangeUserRole は指定されたユーザーのロールを変更します
+// 管理者がいなくなることを防ぐため、自分自身のロールは変更できません
+func (uc *UserUseCase) ChangeUserRole(id uint, role string, currentUserID uint) (*model.User, error) {
+	newRole, err := model.ParseRole(role)
+	if err != nil {
+		return nil, err
+	}
+	if id == currentUserID {
+		return nil, domainerr.Forbidden(i18n.CannotChangeOwnRole)
+	}
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.NotFound(i18n.UserNotFound)
+	}
+	user.ChangeRole(newRole)
+
+	return uc.userRepo.Update(user)
+}
+
+// DeactivateUser は指定されたIDのユーザーを無効化します
+// 無効化されたユーザーはサインインできません。発行済みのトークンとセッションは全て失効させるため、再び有効にしても使用できません
+func (uc *UserUseCase) DeactivateUser(id uint) error {
 	user, err := uc.userRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 	if user == nil {
-		return errors.New("ユーザーが見つかりません")
+		return domainerr.NotFound(i18n.UserNotFound)
+	}
+	if err := uc.authUseCase.LogoutEverywhere(id, nil); err != nil {
+		return err
 	}
-	return uc.userRepo.Remove(id)
+	return uc.userRepo.Deactivate(id)
 }
 
-// Signin はユーザー名とパスワードを検証し、成功時にJWTトークンを返します
-func (uc *UserUseCase) Signin(username, password string) (string, error) {
-	user, err := uc.GetUserByUsername(username)
+// ReactivateUser は無効化されたユーザーを再び有効にします
+func (uc *UserUseCase) ReactivateUser(id uint) (*model.User, error) {
+	user, err := uc.userRepo.FindDeactivatedByID(id)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("ユーザーが見つかりません")
+		return nil, domainerr.NotFound(i18n.DeactivatedUserNotFound)
 	}
-	if !utility.CheckPasswordHash(password, user.Password) {
-		return "", errors.New("パスワードが正しくありません")
+	if err := uc.userRepo.Reactivate(id); err != nil {
+		return nil, err
 	}
-	token, err := utility.GenerateToken(user.ID, user.Username)
+
+	return uc.userRepo.FindByID(id)
+}
+
+// PurgeUser は指定されたIDのユーザーをTodoと共に完全に削除します
+// 無効化されたユーザーも対象です
+func (uc *UserUseCase) PurgeUser(id uint) error {
+	user, err := uc.userRepo.FindByID(id)
 	if err != nil {
-		return "", err
+		return err
 	}
+	if user == nil {
+		user, err = uc.userRepo.FindDeactivatedByID(id)
+		if err != nil {
+			return err
+		}
+	}
+	if user == nil {
+		return domainerr.NotFound(i18n.UserNotFound)
+	}
+	return uc.userRepo.Purge(id)
+}
 
-	return token, nil
+// PurgeDeactivatedUsers は無効化してから保持期間を過ぎたユーザーを完全に削除し、削除件数を返します
+func (uc *UserUseCase) PurgeDeactivatedUsers(retention time.Duration) (int64, error) {
+	return uc.userRepo.PurgeDeactivatedBefore(time.Now().Add(-retention))
 }
@@ -8,87 +8,103 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 )
 
 var jwtSecretKey []byte
-var costFactorValue int
+var accessTokenTTL time.Duration
+var refreshTokenTTL time.Duration
 
 func init() {
 	secretKey := os.Getenv("JWT_SECRET_KEY")
-	costFactorStr := os.Getenv("BCRYPT_COST_FACTOR")
 	if secretKey == "" {
 		secretKey = "fallback_development_key_do_not_use_in_production"
 		fmt.Println("警告: JWT_SECRET_KEYが設定されていません。開発用のキーを使用します。")
 	}
 	jwtSecretKey = []byte(secretKey)
-	if costFactorStr == "" {
-		costFactorValue = 12
-		fmt.Println("警告: BCRYPT_COST_FACTORが設定されていません。デフォルトのコスト値を使用します。")
-	} else {
-		cfValue, err := strconv.Atoi(costFactorStr)
-		if err != nil {
-			fmt.Println("警告: BCRYPT_COST_FACTORの値が無効です。デフォルトの値を使用します。")
-			costFactorValue = 12
-		} else {
-			costFactorValue = cfValue
-		}
-	}
+	initKeyRing()
+	accessTokenTTL = durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+	refreshTokenTTL = durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
 }
 
-// JWTClaims はJWTのペイロード部分です
-type JWTClaims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
-	jwt.RegisteredClaims
+// durationFromEnv は環境変数から期間（例: 15m、720h）を取得します
+// 設定されていないか無効な場合はデフォルト値を返します
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		fmt.Printf("警告: %sの値が無効です。デフォルトの値を使用します。\n", key)
+		return defaultValue
+	}
+
+	return duration
 }
 
-// hashPassword はパスワードをbcryptでハッシュ化します
-func HashPassword(password string) (string, error) {
-	costFactor := costFactorValue
-	combinedPassword := password + string(jwtSecretKey)
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(combinedPassword), costFactor)
-	if err != nil {
-		return "", err
+// intFromEnv は環境変数から整数を取得します
+// 設定されていないか、minValueからmaxValueの範囲外の場合はデフォルト値を返します
+func intFromEnv(key string, defaultValue, minValue, maxValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < minValue || parsed > maxValue {
+		fmt.Printf("警告: %sの値が無効です。デフォルトの値を使用します。\n", key)
+		return defaultValue
 	}
 
-	return string(hashedBytes), nil
+	return parsed
 }
 
-// comparePassword はパスワードとハッシュを比較します
-func VerifyPassword(hashedPassword, password string) error {
-	combinedPassword := password + string(jwtSecretKey)
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(combinedPassword))
-	if err != nil {
-		return err
-	}
+// AccessTokenTTL はアクセストークンの有効期間を返します
+func AccessTokenTTL() time.Duration {
+	return accessTokenTTL
+}
 
-	return nil
+// RefreshTokenTTL はリフレッシュトークンの有効期間を返します
+func RefreshTokenTTL() time.Duration {
+	return refreshTokenTTL
 }
 
-// CheckPasswordHash はパスワードとハッシュが一致するか検証します
-func CheckPasswordHash(password, hash string) bool {
-	combinedPassword := password + string(jwtSecretKey)
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(combinedPassword))
-	return err == nil
+// JWTClaims はJWTのペイロード部分です
+type JWTClaims struct {
+	UserID    uint   `json:"user_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	SessionID uint   `json:"sid"`
+	Scope     string `json:"scope,omitempty"`
+	jwt.RegisteredClaims
 }
 
-// GenerateToken はユーザー情報からJWTトークンを生成します
-func GenerateToken(userID uint, username string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+// GenerateToken はユーザー情報から短期間有効なJWTアクセストークンを生成します
+// トークンはactiveな鍵で署名し、kidヘッダーに鍵IDを設定します
+// 失効させる際にトークンを識別できるよう、jtiクレームに一意なIDを、sidクレームにセッションIDを設定します
+// OAuthクライアントに認可したセッションの場合は、認可されたスコープを空白区切りでscopeクレームに設定します
+// 有効期間はACCESS_TOKEN_TTLで設定できます（省略時は15分）
+func GenerateToken(userID uint, username string, role string, sessionID uint, scope string) (string, error) {
+	now := time.Now()
+	tokenID, err := GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
 	claims := &JWTClaims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		Role:      role,
+		SessionID: sessionID,
+		Scope:     scope,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "golang-todo-app",
 			Subject:   fmt.Sprintf("%d", userID),
+			ID:        tokenID,
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecretKey)
+	tokenString, err := keyRing.sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -97,16 +113,17 @@ func GenerateToken(userID uint, username string) (string, error) {
 }
 
 // ValidateToken はトークンを検証し、有効であればクレームを返します
+// kidヘッダーが示す鍵のうち、廃止済みでない鍵で署名されたトークンを受け付けます
 func ValidateToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return jwtSecretKey, nil
-		},
+		keyRing.verificationKey,
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}),
 	)
 	if err != nil {
 		return nil, err
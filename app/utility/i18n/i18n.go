@@ -0,0 +1,77 @@
+// Package i18n はAPIが返すメッセージの多言語化を行うパッケージです
+// メッセージはIDで管理し、リクエストごとに決定した言語に翻訳して返します
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Language はメッセージの言語です
+type Language string
+
+const (
+	// Japanese は日本語です
+	Japanese Language = "ja"
+	// English は英語です
+	English Language = "en"
+	// Default は言語が決定できない場合に使用する言語です
+	Default = Japanese
+)
+
+// MessageID はメッセージカタログのキーです
+type MessageID string
+
+// supported は対応している言語です。先頭の言語がAccept-Languageと一致しない場合に使われます
+var supported = []Language{Japanese, English}
+
+var matcher = language.NewMatcher([]language.Tag{language.Japanese, language.English})
+
+// ParseLanguage は文字列から対応している言語を取得します
+func ParseLanguage(value string) (Language, bool) {
+	for _, lang := range supported {
+		if string(lang) == value {
+			return lang, true
+		}
+	}
+
+	return "", false
+}
+
+// Negotiate はAccept-Languageヘッダーの値から最も適した言語を返します
+// 対応している言語が含まれない場合や値が不正な場合はDefaultを返します
+func Negotiate(acceptLanguage string) Language {
+	if acceptLanguage == "" {
+		return Default
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+
+	return supported[index]
+}
+
+// Translate はメッセージを指定された言語に翻訳します
+// argsを指定した場合はメッセージを書式としてfmt.Sprintfで展開します
+// 指定された言語の翻訳がない場合はDefaultの翻訳を、メッセージが登録されていない場合はIDをそのまま返します
+func Translate(lang Language, id MessageID, args ...any) string {
+	translations, ok := catalog[id]
+	if !ok {
+		return string(id)
+	}
+	message, ok := translations[lang]
+	if !ok {
+		message = translations[Default]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
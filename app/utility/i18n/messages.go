@@ -0,0 +1,292 @@
+package i18n
+
+// メッセージID
+const (
+	// 共通
+	ProblemNotFound     MessageID = "problem.not_found"
+	ProblemForbidden    MessageID = "problem.forbidden"
+	ProblemConflict     MessageID = "problem.conflict"
+	ProblemValidation   MessageID = "problem.validation"
+	ProblemUnauthorized MessageID = "problem.unauthorized"
+	ProblemInternal     MessageID = "problem.internal"
+	EndpointNotFound    MessageID = "endpoint.not_found"
+
+	// 入力値
+	InvalidRequestBody MessageID = "request.invalid_body"
+	FieldRequired      MessageID = "field.required"
+	FieldEmail         MessageID = "field.email"
+	FieldInvalid       MessageID = "field.invalid"
+	FieldType          MessageID = "field.type"
+	InvalidID          MessageID = "field.invalid_id"
+	InvalidLimit       MessageID = "list.invalid_limit"
+	InvalidCursor      MessageID = "list.invalid_cursor"
+	InvalidSort        MessageID = "list.invalid_sort"
+	InvalidTimestamp   MessageID = "list.invalid_timestamp"
+	InvalidCompleted   MessageID = "list.invalid_completed"
+
+	// 認証
+	AuthRequired         MessageID = "auth.required"
+	AuthHeaderMissing    MessageID = "auth.header_missing"
+	AuthHeaderMalformed  MessageID = "auth.header_malformed"
+	TokenInvalid         MessageID = "auth.token_invalid"
+	TokenClaimsMissing   MessageID = "auth.token_claims_missing"
+	TokenRevoked         MessageID = "auth.token_revoked"
+	SessionEnded         MessageID = "auth.session_ended"
+	AccountDisabled      MessageID = "auth.account_disabled"
+	PermissionDenied     MessageID = "auth.permission_denied"
+	PasswordIncorrect    MessageID = "auth.password_incorrect"
+	RefreshTokenRequired MessageID = "auth.refresh_token_required"
+	RefreshTokenInvalid  MessageID = "auth.refresh_token_invalid"
+	RefreshTokenReused   MessageID = "auth.refresh_token_reused"
+	FutureTimeNotAllowed MessageID = "auth.future_time"
+	SigninSucceeded      MessageID = "auth.signin_succeeded"
+	LoggedOut            MessageID = "auth.logged_out"
+	LoggedOutEverywhere  MessageID = "auth.logged_out_everywhere"
+	SessionNotFound      MessageID = "session.not_found"
+	SessionForbidden     MessageID = "session.forbidden"
+	SessionRevoked       MessageID = "session.revoked"
+
+	// ユーザー
+	UserNotFound            MessageID = "user.not_found"
+	DeactivatedUserNotFound MessageID = "user.deactivated_not_found"
+	UserFieldsRequired      MessageID = "user.fields_required"
+	UserAlreadyExists       MessageID = "user.already_exists"
+	PasswordMismatch        MessageID = "user.password_mismatch"
+	PasswordTooShort        MessageID = "user.password_too_short"
+	PasswordTooLong         MessageID = "user.password_too_long"
+	PasswordTooWeak         MessageID = "user.password_too_weak"
+	PasswordUnchanged       MessageID = "user.password_unchanged"
+	PasswordChanged         MessageID = "user.password_changed"
+	InvalidRole             MessageID = "user.invalid_role"
+	CannotChangeOwnRole     MessageID = "user.cannot_change_own_role"
+
+	// Todo
+	TodoNotFound          MessageID = "todo.not_found"
+	TodoForbidden         MessageID = "todo.forbidden"
+	TodoTitleRequired     MessageID = "todo.title_required"
+	TodoUserIDRequired    MessageID = "todo.user_id_required"
+	ParentTodoNotFound    MessageID = "todo.parent_not_found"
+	ParentTodoCompleted   MessageID = "todo.parent_completed"
+	ParentTodoTrashed     MessageID = "todo.parent_trashed"
+	OpenSubtasksRemain    MessageID = "todo.open_subtasks"
+	StartAfterDue         MessageID = "todo.start_after_due"
+	RecurrenceRequiresDue MessageID = "todo.recurrence_requires_due"
+	InvalidRecurrence     MessageID = "todo.invalid_recurrence"
+	InvalidPriority       MessageID = "todo.invalid_priority"
+	InvalidDue            MessageID = "todo.invalid_due"
+	InvalidDueWithin      MessageID = "todo.invalid_due_within"
+	InvalidTagMatch       MessageID = "todo.invalid_tag_match"
+	InvalidProjectID      MessageID = "todo.invalid_project_id"
+	SearchQueryRequired   MessageID = "todo.search_query_required"
+	TodoDeleted           MessageID = "todo.deleted"
+	TodoPurged            MessageID = "todo.purged"
+
+	// 繰り返しルール
+	RecurrenceEmpty                  MessageID = "recurrence.empty"
+	RecurrenceMalformed              MessageID = "recurrence.malformed"
+	RecurrenceDuplicatePart          MessageID = "recurrence.duplicate_part"
+	RecurrenceUnsupportedPart        MessageID = "recurrence.unsupported_part"
+	RecurrenceFreqRequired           MessageID = "recurrence.freq_required"
+	RecurrenceUnsupportedFreq        MessageID = "recurrence.unsupported_freq"
+	RecurrenceNotPositive            MessageID = "recurrence.not_positive"
+	RecurrenceInvalidUntil           MessageID = "recurrence.invalid_until"
+	RecurrenceInvalidByDay           MessageID = "recurrence.invalid_by_day"
+	RecurrenceInvalidOrdinal         MessageID = "recurrence.invalid_ordinal"
+	RecurrenceInvalidMonthDay        MessageID = "recurrence.invalid_month_day"
+	RecurrenceInvalidWeekday         MessageID = "recurrence.invalid_weekday"
+	RecurrenceCountWithUntil         MessageID = "recurrence.count_with_until"
+	RecurrenceOrdinalRequiresMonthly MessageID = "recurrence.ordinal_requires_monthly"
+	RecurrenceYearlyByDay            MessageID = "recurrence.yearly_by_day"
+	RecurrenceNeverMatches           MessageID = "recurrence.never_matches"
+
+	// プロジェクト
+	ProjectNotFound     MessageID = "project.not_found"
+	ProjectForbidden    MessageID = "project.forbidden"
+	ProjectNameRequired MessageID = "project.name_required"
+	ProjectNameTooLong  MessageID = "project.name_too_long"
+	ProjectNameTaken    MessageID = "project.name_taken"
+	ProjectArchived     MessageID = "project.archived"
+	ProjectDeleted      MessageID = "project.deleted"
+
+	// 設定
+	InvalidTimezone  MessageID = "settings.invalid_timezone"
+	InvalidLocale    MessageID = "settings.invalid_locale"
+	InvalidWeekStart MessageID = "settings.invalid_week_start"
+
+	// タグ
+	TagNotFound     MessageID = "tag.not_found"
+	TagForbidden    MessageID = "tag.forbidden"
+	TagNameRequired MessageID = "tag.name_required"
+	TagNameTooLong  MessageID = "tag.name_too_long"
+	TagNameTaken    MessageID = "tag.name_taken"
+	TagDeleted      MessageID = "tag.deleted"
+
+	// アクセストークン
+	AccessTokenNotFound     MessageID = "access_token.not_found"
+	AccessTokenForbidden    MessageID = "access_token.forbidden"
+	AccessTokenNameRequired MessageID = "access_token.name_required"
+	AccessTokenNameTooLong  MessageID = "access_token.name_too_long"
+	AccessTokenExpired      MessageID = "access_token.expired"
+	AccessTokenDeleted      MessageID = "access_token.deleted"
+	ExpiryInPast            MessageID = "access_token.expiry_in_past"
+
+	// スコープ
+	ScopesRequired    MessageID = "scope.required"
+	InvalidScope      MessageID = "scope.invalid"
+	ScopeNotAllowed   MessageID = "scope.not_allowed"
+	InsufficientScope MessageID = "scope.insufficient"
+	SessionRequired   MessageID = "auth.session_required"
+
+	// OAuth
+	OAuthClientNotFound        MessageID = "oauth.client_not_found"
+	OAuthClientNameRequired    MessageID = "oauth.client_name_required"
+	OAuthClientNameTooLong     MessageID = "oauth.client_name_too_long"
+	OAuthClientDeleted         MessageID = "oauth.client_deleted"
+	RedirectURIsRequired       MessageID = "oauth.redirect_uris_required"
+	InvalidRedirectURI         MessageID = "oauth.invalid_redirect_uri"
+	RedirectURIMismatch        MessageID = "oauth.redirect_uri_mismatch"
+	UnsupportedResponseType    MessageID = "oauth.unsupported_response_type"
+	CodeChallengeRequired      MessageID = "oauth.code_challenge_required"
+	UnsupportedChallengeMethod MessageID = "oauth.unsupported_challenge_method"
+	ScopeNotRegistered         MessageID = "oauth.scope_not_registered"
+	UnsupportedGrantType       MessageID = "oauth.unsupported_grant_type"
+	OAuthParameterRequired     MessageID = "oauth.parameter_required"
+	ClientAuthenticationFailed MessageID = "oauth.client_authentication_failed"
+	AuthorizationCodeInvalid   MessageID = "oauth.authorization_code_invalid"
+	AuthorizationCodeReused    MessageID = "oauth.authorization_code_reused"
+	CodeVerifierInvalid        MessageID = "oauth.code_verifier_invalid"
+)
+
+// catalog はメッセージIDと言語ごとのメッセージの対応です
+var catalog = map[MessageID]map[Language]string{
+	ProblemNotFound:                  {Japanese: "対象が見つかりません", English: "Not found"},
+	ProblemForbidden:                 {Japanese: "権限がありません", English: "Forbidden"},
+	ProblemConflict:                  {Japanese: "現在の状態では実行できません", English: "Conflict"},
+	ProblemValidation:                {Japanese: "入力内容に誤りがあります", English: "Validation failed"},
+	ProblemUnauthorized:              {Japanese: "認証が必要です", English: "Unauthorized"},
+	ProblemInternal:                  {Japanese: "サーバー内部でエラーが発生しました", English: "Internal server error"},
+	EndpointNotFound:                 {Japanese: "エンドポイントが見つかりません", English: "Endpoint not found"},
+	InvalidRequestBody:               {Japanese: "リクエストボディが不正です", English: "The request body is invalid"},
+	FieldRequired:                    {Japanese: "%sは必須です", English: "%s is required"},
+	FieldEmail:                       {Japanese: "%sはメールアドレスの形式で指定してください", English: "%s must be a valid email address"},
+	FieldInvalid:                     {Japanese: "%sが不正です", English: "%s is invalid"},
+	FieldType:                        {Japanese: "%sの型が不正です", English: "%s has an invalid type"},
+	InvalidID:                        {Japanese: "無効なIDです", English: "Invalid ID"},
+	InvalidLimit:                     {Japanese: "limitには1から%dまでの数値を指定してください", English: "limit must be a number between 1 and %d"},
+	InvalidCursor:                    {Japanese: "カーソルが不正です", English: "The cursor is invalid"},
+	InvalidSort:                      {Japanese: "並び順が不正です", English: "The sort order is invalid"},
+	InvalidTimestamp:                 {Japanese: "%sはRFC3339形式で指定してください", English: "%s must be in RFC 3339 format"},
+	InvalidCompleted:                 {Japanese: "completedにはtrueまたはfalseを指定してください", English: "completed must be true or false"},
+	AuthRequired:                     {Japanese: "認証が必要です", English: "Authentication is required"},
+	AuthHeaderMissing:                {Japanese: "認証ヘッダーがありません", English: "The Authorization header is missing"},
+	AuthHeaderMalformed:              {Japanese: "認証形式が不正です", English: "The Authorization header must use the Bearer scheme"},
+	TokenInvalid:                     {Japanese: "無効なトークン", English: "Invalid token"},
+	TokenClaimsMissing:               {Japanese: "無効なトークン: 必要なクレームがありません", English: "Invalid token: required claims are missing"},
+	TokenRevoked:                     {Japanese: "トークンは失効しています", English: "The token has been revoked"},
+	SessionEnded:                     {Japanese: "セッションは終了しています", English: "The session has ended"},
+	AccountDisabled:                  {Japanese: "アカウントが無効です", English: "The account is disabled"},
+	PermissionDenied:                 {Japanese: "この操作を行う権限がありません", English: "You do not have permission to perform this action"},
+	PasswordIncorrect:                {Japanese: "パスワードが正しくありません", English: "The password is incorrect"},
+	RefreshTokenRequired:             {Japanese: "リフレッシュトークンを指定してください", English: "A refresh token is required"},
+	RefreshTokenInvalid:              {Japanese: "リフレッシュトークンが無効です", English: "The refresh token is invalid"},
+	RefreshTokenReused:               {Japanese: "リフレッシュトークンが再利用されたため、全てのトークンを失効させました。再度ログインしてください", English: "The refresh token was reused, so all tokens have been revoked. Please sign in again"},
+	FutureTimeNotAllowed:             {Japanese: "未来の日時は指定できません", English: "A future time cannot be specified"},
+	SigninSucceeded:                  {Japanese: "ログイン成功", English: "Signed in"},
+	LoggedOut:                        {Japanese: "ログアウトしました", English: "Logged out"},
+	LoggedOutEverywhere:              {Japanese: "全ての端末からログアウトしました", English: "Logged out from all devices"},
+	SessionNotFound:                  {Japanese: "セッションが見つかりません", English: "Session not found"},
+	SessionForbidden:                 {Japanese: "このセッションを操作する権限がありません", English: "You do not have permission to access this session"},
+	SessionRevoked:                   {Japanese: "セッションを終了しました", English: "The session has been ended"},
+	UserNotFound:                     {Japanese: "ユーザーが見つかりません", English: "User not found"},
+	DeactivatedUserNotFound:          {Japanese: "無効化されたユーザーが見つかりません", English: "Deactivated user not found"},
+	UserFieldsRequired:               {Japanese: "ユーザー名、パスワード、メールアドレスは必須です", English: "Username, password and email are required"},
+	UserAlreadyExists:                {Japanese: "ユーザー名またはメールアドレスは既に使用されています", English: "The username or email address is already in use"},
+	PasswordMismatch:                 {Japanese: "パスワードが一致しません", English: "The passwords do not match"},
+	PasswordTooShort:                 {Japanese: "パスワードは%d文字以上で指定してください", English: "The password must be at least %d characters"},
+	PasswordTooLong:                  {Japanese: "パスワードは%dバイト以内で指定してください", English: "The password must be at most %d bytes"},
+	PasswordTooWeak:                  {Japanese: "パスワードには英字と数字をそれぞれ1文字以上含めてください", English: "The password must contain at least one letter and one digit"},
+	PasswordUnchanged:                {Japanese: "新しいパスワードには現在のパスワードと異なるものを指定してください", English: "The new password must be different from the current password"},
+	PasswordChanged:                  {Japanese: "パスワードを変更しました。再度ログインしてください", English: "The password has been changed. Please sign in again"},
+	InvalidRole:                      {Japanese: "ロールにはuserまたはadminを指定してください: %s", English: "role must be user or admin: %s"},
+	CannotChangeOwnRole:              {Japanese: "自分自身のロールは変更できません", English: "You cannot change your own role"},
+	TodoNotFound:                     {Japanese: "Todoが見つかりません", English: "Todo not found"},
+	TodoForbidden:                    {Japanese: "このTodoを操作する権限がありません", English: "You do not have permission to access this todo"},
+	TodoTitleRequired:                {Japanese: "タイトルは必須です", English: "title is required"},
+	TodoUserIDRequired:               {Japanese: "ユーザーIDは必須です", English: "A user ID is required"},
+	ParentTodoNotFound:               {Japanese: "親タスクが見つかりません", English: "Parent todo not found"},
+	ParentTodoCompleted:              {Japanese: "完了済みのTodoにはサブタスクを追加できません", English: "Subtasks cannot be added to a completed todo"},
+	ParentTodoTrashed:                {Japanese: "親タスクがゴミ箱にあるため復元できません", English: "The todo cannot be restored because its parent is in the trash"},
+	OpenSubtasksRemain:               {Japanese: "未完了のサブタスクがあるため完了にできません", English: "The todo cannot be completed because it has open subtasks"},
+	StartAfterDue:                    {Japanese: "開始日時は期限より前に設定してください", English: "start_at must be before due_at"},
+	RecurrenceRequiresDue:            {Japanese: "繰り返しを設定するには期限が必要です", English: "A due date is required for a recurring todo"},
+	InvalidRecurrence:                {Japanese: "繰り返しルールが不正です", English: "The recurrence rule is invalid"},
+	InvalidPriority:                  {Japanese: "優先度はnone, low, medium, high, urgentのいずれかを指定してください", English: "priority must be one of none, low, medium, high or urgent"},
+	InvalidDue:                       {Japanese: "dueにはoverdue、todayまたはweekを指定してください", English: "due must be overdue, today or week"},
+	InvalidDueWithin:                 {Japanese: "due_withinには1以上の日数を指定してください", English: "due_within must be a positive number of days"},
+	InvalidTagMatch:                  {Japanese: "tag_matchにはanyまたはallを指定してください", English: "tag_match must be any or all"},
+	InvalidProjectID:                 {Japanese: "無効なプロジェクトIDです", English: "Invalid project ID"},
+	SearchQueryRequired:              {Japanese: "検索語を指定してください", English: "A search query is required"},
+	TodoDeleted:                      {Japanese: "Todoを削除しました", English: "The todo has been moved to the trash"},
+	TodoPurged:                       {Japanese: "Todoを完全に削除しました", English: "The todo has been permanently deleted"},
+	RecurrenceEmpty:                  {Japanese: "繰り返しルールが空です", English: "The recurrence rule is empty"},
+	RecurrenceMalformed:              {Japanese: "繰り返しルールの形式が不正です: %s", English: "The recurrence rule is malformed: %s"},
+	RecurrenceDuplicatePart:          {Japanese: "繰り返しルールの%sが重複しています", English: "The recurrence rule part %s is specified more than once"},
+	RecurrenceUnsupportedPart:        {Japanese: "繰り返しルールの%sはサポートされていません", English: "The recurrence rule part %s is not supported"},
+	RecurrenceFreqRequired:           {Japanese: "繰り返しルールにはFREQが必要です", English: "FREQ is required in the recurrence rule"},
+	RecurrenceUnsupportedFreq:        {Japanese: "FREQにはDAILY、WEEKLY、MONTHLYまたはYEARLYを指定してください: %s", English: "FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY: %s"},
+	RecurrenceNotPositive:            {Japanese: "%sには1以上の整数を指定してください", English: "%s must be a positive integer"},
+	RecurrenceInvalidUntil:           {Japanese: "UNTILの形式が不正です: %s", English: "UNTIL is malformed: %s"},
+	RecurrenceInvalidByDay:           {Japanese: "BYDAYの形式が不正です: %s", English: "BYDAY is malformed: %s"},
+	RecurrenceInvalidOrdinal:         {Japanese: "BYDAYの序数には-5から5（0を除く）を指定してください: %s", English: "The BYDAY ordinal must be between -5 and 5, excluding 0: %s"},
+	RecurrenceInvalidMonthDay:        {Japanese: "BYMONTHDAYには-31から31（0を除く）を指定してください: %s", English: "BYMONTHDAY must be between -31 and 31, excluding 0: %s"},
+	RecurrenceInvalidWeekday:         {Japanese: "曜日の形式が不正です: %s", English: "The day of the week is malformed: %s"},
+	RecurrenceCountWithUntil:         {Japanese: "COUNTとUNTILは同時に指定できません", English: "COUNT and UNTIL cannot be used together"},
+	RecurrenceOrdinalRequiresMonthly: {Japanese: "序数付きのBYDAYはFREQ=MONTHLYでのみ指定できます: %s", English: "A BYDAY ordinal can only be used with FREQ=MONTHLY: %s"},
+	RecurrenceYearlyByDay:            {Japanese: "FREQ=YEARLYではBYDAYはサポートされていません", English: "BYDAY is not supported with FREQ=YEARLY"},
+	RecurrenceNeverMatches:           {Japanese: "BYDAYとBYMONTHDAYの両方に一致する日付がありません", English: "No date matches both BYDAY and BYMONTHDAY"},
+	ProjectNotFound:                  {Japanese: "プロジェクトが見つかりません", English: "Project not found"},
+	ProjectForbidden:                 {Japanese: "このプロジェクトを操作する権限がありません", English: "You do not have permission to access this project"},
+	ProjectNameRequired:              {Japanese: "プロジェクト名は必須です", English: "The project name is required"},
+	ProjectNameTooLong:               {Japanese: "プロジェクト名は%d文字以内で指定してください", English: "The project name must be at most %d characters"},
+	ProjectNameTaken:                 {Japanese: "同じ名前のプロジェクトが既に存在します", English: "A project with the same name already exists"},
+	ProjectArchived:                  {Japanese: "アーカイブされたプロジェクトにはTodoを追加できません", English: "Todos cannot be added to an archived project"},
+	ProjectDeleted:                   {Japanese: "プロジェクトを削除しました", English: "The project has been deleted"},
+	InvalidTimezone:                  {Japanese: "タイムゾーンが不正です: %s", English: "Unknown time zone: %s"},
+	InvalidLocale:                    {Japanese: "localeにはjaまたはenを指定してください", English: "locale must be ja or en"},
+	InvalidWeekStart:                 {Japanese: "week_startには曜日（sunday、mondayなど）を指定してください", English: "week_start must be a day of the week such as sunday or monday"},
+	TagNotFound:                      {Japanese: "タグが見つかりません", English: "Tag not found"},
+	TagForbidden:                     {Japanese: "このタグを操作する権限がありません", English: "You do not have permission to access this tag"},
+	TagNameRequired:                  {Japanese: "タグ名は必須です", English: "The tag name is required"},
+	TagNameTooLong:                   {Japanese: "タグ名は%d文字以内で指定してください", English: "The tag name must be at most %d characters"},
+	TagNameTaken:                     {Japanese: "同じ名前のタグが既に存在します", English: "A tag with the same name already exists"},
+	TagDeleted:                       {Japanese: "タグを削除しました", English: "The tag has been deleted"},
+	AccessTokenNotFound:              {Japanese: "アクセストークンが見つかりません", English: "Access token not found"},
+	AccessTokenForbidden:             {Japanese: "このアクセストークンを操作する権限がありません", English: "You do not have permission to manage this access token"},
+	AccessTokenNameRequired:          {Japanese: "アクセストークンの名前は必須です", English: "The access token name is required"},
+	AccessTokenNameTooLong:           {Japanese: "アクセストークンの名前は%d文字以内で指定してください", English: "The access token name must be at most %d characters"},
+	AccessTokenExpired:               {Japanese: "アクセストークンの有効期限が切れています", English: "The access token has expired"},
+	AccessTokenDeleted:               {Japanese: "アクセストークンを削除しました", English: "The access token has been deleted"},
+	ExpiryInPast:                     {Japanese: "有効期限には未来の日時を指定してください", English: "The expiry must be in the future"},
+	ScopesRequired:                   {Japanese: "スコープを1つ以上指定してください", English: "At least one scope is required"},
+	InvalidScope:                     {Japanese: "スコープ%sは無効です", English: "The scope %s is invalid"},
+	ScopeNotAllowed:                  {Japanese: "スコープ%sを付与する権限がありません", English: "You are not allowed to grant the scope %s"},
+	InsufficientScope:                {Japanese: "この操作にはスコープ%sが必要です", English: "This operation requires the scope %s"},
+	SessionRequired:                  {Japanese: "この操作には個人用アクセストークンやOAuthのアクセストークンを使用できません", English: "Personal access tokens and OAuth access tokens cannot be used for this operation"},
+	OAuthClientNotFound:              {Japanese: "OAuthクライアントが見つかりません", English: "OAuth client not found"},
+	OAuthClientNameRequired:          {Japanese: "OAuthクライアントの名前は必須です", English: "The OAuth client name is required"},
+	OAuthClientNameTooLong:           {Japanese: "OAuthクライアントの名前は%d文字以内で指定してください", English: "The OAuth client name must be at most %d characters"},
+	OAuthClientDeleted:               {Japanese: "OAuthクライアントを削除しました", English: "The OAuth client has been deleted"},
+	RedirectURIsRequired:             {Japanese: "リダイレクトURIを1つ以上指定してください", English: "At least one redirect URI is required"},
+	InvalidRedirectURI:               {Japanese: "リダイレクトURI %s は無効です。httpsまたはループバックアドレスへのhttpの絶対URIを指定してください", English: "The redirect URI %s is invalid. Use an absolute https URI or an http URI for a loopback address"},
+	RedirectURIMismatch:              {Japanese: "リダイレクトURIがクライアントに登録されていません", English: "The redirect URI is not registered for the client"},
+	UnsupportedResponseType:          {Japanese: "response_typeにはcodeを指定してください", English: "response_type must be code"},
+	CodeChallengeRequired:            {Japanese: "PKCEのcode_challengeを指定してください", English: "A PKCE code_challenge is required"},
+	UnsupportedChallengeMethod:       {Japanese: "code_challenge_methodにはS256を指定してください", English: "code_challenge_method must be S256"},
+	ScopeNotRegistered:               {Japanese: "スコープ%sはクライアントに許可されていません", English: "The scope %s is not allowed for the client"},
+	UnsupportedGrantType:             {Japanese: "grant_typeにはauthorization_codeまたはrefresh_tokenを指定してください", English: "grant_type must be authorization_code or refresh_token"},
+	OAuthParameterRequired:           {Japanese: "%sを指定してください", English: "%s is required"},
+	ClientAuthenticationFailed:       {Japanese: "クライアント認証に失敗しました", English: "Client authentication failed"},
+	AuthorizationCodeInvalid:         {Japanese: "認可コードが無効か、有効期限が切れています", English: "The authorization code is invalid or has expired"},
+	AuthorizationCodeReused:          {Japanese: "認可コードは既に使用されています。このコードで発行されたトークンを失効させました", English: "The authorization code has already been used. Tokens issued with it have been revoked"},
+	CodeVerifierInvalid:              {Japanese: "code_verifierが一致しません", English: "The code_verifier does not match"},
+}
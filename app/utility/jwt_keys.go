@@ -0,0 +1,271 @@
+package utility
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// KeyStatusActive は新しいトークンの署名に使用する鍵の状態です
+	KeyStatusActive = "active"
+	// KeyStatusVerify はローテーション中のため、署名済みのトークンの検証にのみ使用する鍵の状態です
+	KeyStatusVerify = "verify"
+	// KeyStatusRetired は廃止済みで、署名されたトークンを受け付けない鍵の状態です
+	KeyStatusRetired = "retired"
+)
+
+// defaultKeyID はJWT_KEYSを設定せずにJWT_SECRET_KEYを使用する場合の鍵IDです
+// kidヘッダーを持たないトークンもこの鍵で検証します
+const defaultKeyID = "default"
+
+// minHMACKeySize はJWT_KEYSで指定するHS256の鍵の最小バイト数です
+const minHMACKeySize = 32
+
+// minRSAKeyBits はRS256の鍵の最小ビット数です
+const minRSAKeyBits = 2048
+
+// keyConfig はJWT_KEYSまたはJWT_KEYS_FILEで指定する鍵の設定です
+type keyConfig struct {
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	KeyFile   string `json:"key_file"`
+}
+
+// SigningKey はJWTの署名と検証に使用する鍵です
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Status    string
+	method    jwt.SigningMethod
+	// signKey は署名用の鍵です。検証用の公開鍵のみを設定した場合はnilです
+	signKey   any
+	verifyKey any
+}
+
+// KeyRing はJWTの署名鍵の集合です
+// 鍵をローテーションする間は、新しい鍵で署名しつつ以前の鍵で署名されたトークンも受け付けます
+type KeyRing struct {
+	current *SigningKey
+	keys    map[string]*SigningKey
+	// ordered は設定された順に並べた鍵です
+	ordered []*SigningKey
+}
+
+var keyRing *KeyRing
+
+// initKeyRing はJWTの署名鍵を読み込みます
+// 鍵の設定が無効な場合は、意図しない鍵でトークンを発行しないよう起動を中止します
+func initKeyRing() {
+	ring, err := loadKeyRing()
+	if err != nil {
+		fmt.Printf("エラー: JWTの署名鍵を読み込めませんでした: %v\n", err)
+		os.Exit(1)
+	}
+	keyRing = ring
+}
+
+// loadKeyRing はJWT_KEYS（JSON）またはJWT_KEYS_FILE（JSONファイルのパス）から鍵を読み込みます
+// どちらも設定されていない場合は、JWT_SECRET_KEYをHS256の鍵として使用します
+func loadKeyRing() (*KeyRing, error) {
+	data := []byte(os.Getenv("JWT_KEYS"))
+	if path := os.Getenv("JWT_KEYS_FILE"); len(data) == 0 && path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		key := &SigningKey{
+			ID:        defaultKeyID,
+			Algorithm: jwt.SigningMethodHS256.Alg(),
+			Status:    KeyStatusActive,
+			method:    jwt.SigningMethodHS256,
+			signKey:   jwtSecretKey,
+			verifyKey: jwtSecretKey,
+		}
+		return &KeyRing{current: key, keys: map[string]*SigningKey{key.ID: key}, ordered: []*SigningKey{key}}, nil
+	}
+	var configs []keyConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("鍵の設定はJSONの配列で指定してください: %w", err)
+	}
+
+	return newKeyRing(configs)
+}
+
+// newKeyRing は鍵の設定から鍵の集合を作成します。署名に使用するactiveな鍵はちょうど1つ必要です
+func newKeyRing(configs []keyConfig) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string]*SigningKey)}
+	for _, config := range configs {
+		key, err := parseSigningKey(config)
+		if err != nil {
+			return nil, fmt.Errorf("鍵 %q: %w", config.ID, err)
+		}
+		if _, exists := ring.keys[key.ID]; exists {
+			return nil, fmt.Errorf("鍵ID %q が重複しています", key.ID)
+		}
+		ring.keys[key.ID] = key
+		ring.ordered = append(ring.ordered, key)
+		if key.Status != KeyStatusActive {
+			continue
+		}
+		if ring.current != nil {
+			return nil, errors.New("activeな鍵は1つだけ指定してください")
+		}
+		if key.signKey == nil {
+			return nil, fmt.Errorf("鍵 %q: activeな鍵には秘密鍵を指定してください", key.ID)
+		}
+		ring.current = key
+	}
+	if ring.current == nil {
+		return nil, errors.New("activeな鍵を1つ指定してください")
+	}
+
+	return ring, nil
+}
+
+// parseSigningKey は鍵の設定を解析します
+// HS256は共有鍵の文字列、RS256とEdDSAはPEM形式の秘密鍵または公開鍵をkeyかkey_fileで指定します
+func parseSigningKey(config keyConfig) (*SigningKey, error) {
+	if config.ID == "" {
+		return nil, errors.New("kidを指定してください")
+	}
+	status := config.Status
+	if status == "" {
+		status = KeyStatusVerify
+	}
+	if status != KeyStatusActive && status != KeyStatusVerify && status != KeyStatusRetired {
+		return nil, fmt.Errorf("statusには%s、%sまたは%sを指定してください", KeyStatusActive, KeyStatusVerify, KeyStatusRetired)
+	}
+	material := []byte(config.Key)
+	if config.KeyFile != "" {
+		var err error
+		material, err = os.ReadFile(config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(material) == 0 {
+		return nil, errors.New("keyまたはkey_fileを指定してください")
+	}
+	key := &SigningKey{ID: config.ID, Algorithm: config.Algorithm, Status: status}
+	switch config.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if len(material) < minHMACKeySize {
+			return nil, fmt.Errorf("HS256の鍵は%dバイト以上で指定してください", minHMACKeySize)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = material
+		key.verifyKey = material
+	case jwt.SigningMethodRS256.Alg():
+		key.method = jwt.SigningMethodRS256
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(material); err == nil {
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+		} else if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(material); err == nil {
+			key.verifyKey = publicKey
+		} else {
+			return nil, errors.New("RS256の鍵はPEM形式のRSA秘密鍵または公開鍵で指定してください")
+		}
+		if key.verifyKey.(*rsa.PublicKey).N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RS256の鍵は%dビット以上で指定してください", minRSAKeyBits)
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		key.method = jwt.SigningMethodEdDSA
+		if privateKey, err := jwt.ParseEdPrivateKeyFromPEM(material); err == nil {
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(crypto.Signer).Public()
+		} else if publicKey, err := jwt.ParseEdPublicKeyFromPEM(material); err == nil {
+			key.verifyKey = publicKey
+		} else {
+			return nil, errors.New("EdDSAの鍵はPEM形式のEd25519秘密鍵または公開鍵で指定してください")
+		}
+	default:
+		return nil, fmt.Errorf("algには%s、%sまたは%sを指定してください",
+			jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg())
+	}
+
+	return key, nil
+}
+
+// sign はactiveな鍵でトークンに署名し、kidヘッダーに鍵IDを設定します
+func (r *KeyRing) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.current.method, claims)
+	token.Header["kid"] = r.current.ID
+
+	return token.SignedString(r.current.signKey)
+}
+
+// verificationKey はトークンのkidヘッダーとalgヘッダーから検証に使用する鍵を返します
+// 廃止済みの鍵や、鍵と異なるアルゴリズムで署名されたトークンは受け付けません
+func (r *KeyRing) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = defaultKeyID
+	}
+	key, ok := r.keys[kid]
+	if !ok || key.Status == KeyStatusRetired {
+		return nil, fmt.Errorf("unknown or retired signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// JSONWebKey はJWKS（RFC 7517）で公開する公開鍵です
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet はJWKSの本体です
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// PublicJWKS はトークンの検証に使用できる公開鍵の一覧を返します
+// HS256の共有鍵と廃止済みの鍵は含めません
+func PublicJWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keyRing.ordered {
+		if key.Status == KeyStatusRetired {
+			continue
+		}
+		jwk := JSONWebKey{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
@@ -0,0 +1,269 @@
+package utility
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testHMACKey はテストで使用するHS256の鍵です
+const testHMACKey = "0123456789abcdef0123456789abcdef"
+
+func rsaKeyPEM(t *testing.T, bits int) (privatePEM, publicPEM string, publicKey *rsa.PublicKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodePrivatePEM(t, key), encodePublicPEM(t, &key.PublicKey), &key.PublicKey
+}
+
+func ed25519KeyPEM(t *testing.T) (privatePEM, publicPEM string, publicKey ed25519.PublicKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodePrivatePEM(t, private), encodePublicPEM(t, public), public
+}
+
+func encodePrivatePEM(t *testing.T, privateKey any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func encodePublicPEM(t *testing.T, publicKey any) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// useKeyRing はテストの間だけ鍵の集合を置き換えます
+func useKeyRing(t *testing.T, configs []keyConfig) {
+	t.Helper()
+	ring, err := newKeyRing(configs)
+	if err != nil {
+		t.Fatalf("newKeyRing() error = %v", err)
+	}
+	previous := keyRing
+	keyRing = ring
+	t.Cleanup(func() { keyRing = previous })
+}
+
+func tokenKeyID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestKeyRingSignsWithActiveKey(t *testing.T) {
+	rsaPrivate, _, _ := rsaKeyPEM(t, 2048)
+	edPrivate, _, _ := ed25519KeyPEM(t)
+	tests := []struct {
+		name    string
+		configs []keyConfig
+		wantKID string
+		wantAlg string
+	}{
+		{
+			name:    "RS256",
+			configs: []keyConfig{{ID: "rsa-1", Algorithm: "RS256", Status: KeyStatusActive, Key: rsaPrivate}},
+			wantKID: "rsa-1",
+			wantAlg: "RS256",
+		},
+		{
+			name: "EdDSA",
+			configs: []keyConfig{
+				{ID: "hs-1", Algorithm: "HS256", Status: KeyStatusVerify, Key: testHMACKey},
+				{ID: "ed-1", Algorithm: "EdDSA", Status: KeyStatusActive, Key: edPrivate},
+			},
+			wantKID: "ed-1",
+			wantAlg: "EdDSA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useKeyRing(t, tt.configs)
+			token, err := GenerateToken(1, "alice", "user", 1, "")
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid := parsed.Header["kid"]; kid != tt.wantKID {
+				t.Errorf("kid = %v, want %s", kid, tt.wantKID)
+			}
+			if alg := parsed.Header["alg"]; alg != tt.wantAlg {
+				t.Errorf("alg = %v, want %s", alg, tt.wantAlg)
+			}
+			claims, err := ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if claims.UserID != 1 {
+				t.Errorf("UserID = %d, want 1", claims.UserID)
+			}
+		})
+	}
+}
+
+func TestKeyRingVerifiesByKeyID(t *testing.T) {
+	oldPrivate, oldPublic, _ := rsaKeyPEM(t, 2048)
+	newPrivate, _, _ := ed25519KeyPEM(t)
+
+	// ローテーション前の鍵で署名したトークンです
+	useKeyRing(t, []keyConfig{{ID: "old", Algorithm: "RS256", Status: KeyStatusActive, Key: oldPrivate}})
+	oldToken, err := GenerateToken(1, "alice", "user", 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &JWTClaims{UserID: 1})
+	forged.Header["kid"] = "old"
+	// 公開鍵をHS256の共有鍵として使うアルゴリズムの混同を狙ったトークンです
+	forgedToken, err := forged.SignedString([]byte(oldPublic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, &JWTClaims{UserID: 1})
+	unknown.Header["kid"] = "unknown"
+	unknownToken, err := unknown.SignedString([]byte(testHMACKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		oldStatus string
+		oldKey    string
+		token     string
+		wantErr   bool
+	}{
+		{"検証用の鍵で署名されたトークンを受け付ける", KeyStatusVerify, oldPrivate, oldToken, false},
+		{"検証用に公開鍵のみを指定できる", KeyStatusVerify, oldPublic, oldToken, false},
+		{"廃止済みの鍵で署名されたトークンは受け付けない", KeyStatusRetired, oldPublic, oldToken, true},
+		{"鍵と異なるアルゴリズムのトークンは受け付けない", KeyStatusVerify, oldPublic, forgedToken, true},
+		{"未知の鍵IDのトークンは受け付けない", KeyStatusVerify, oldPublic, unknownToken, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useKeyRing(t, []keyConfig{
+				{ID: "old", Algorithm: "RS256", Status: tt.oldStatus, Key: tt.oldKey},
+				{ID: "new", Algorithm: "EdDSA", Status: KeyStatusActive, Key: newPrivate},
+			})
+			_, err := ValidateToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			token, err := GenerateToken(1, "alice", "user", 1, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid := tokenKeyID(t, token); kid != "new" {
+				t.Errorf("new tokens are signed with %q, want new", kid)
+			}
+		})
+	}
+}
+
+func TestKeyRingVerifiesTokenWithoutKeyID(t *testing.T) {
+	useKeyRing(t, []keyConfig{{ID: defaultKeyID, Algorithm: "HS256", Status: KeyStatusActive, Key: testHMACKey}})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &JWTClaims{UserID: 1}).SignedString([]byte(testHMACKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() error = %v for a token without kid", err)
+	}
+}
+
+func TestPublicJWKS(t *testing.T) {
+	_, rsaPublic, rsaKey := rsaKeyPEM(t, 2048)
+	edPrivate, _, edKey := ed25519KeyPEM(t)
+	_, retiredPublic, _ := ed25519KeyPEM(t)
+	useKeyRing(t, []keyConfig{
+		{ID: "hs", Algorithm: "HS256", Status: KeyStatusVerify, Key: testHMACKey},
+		{ID: "rsa", Algorithm: "RS256", Status: KeyStatusVerify, Key: rsaPublic},
+		{ID: "ed", Algorithm: "EdDSA", Status: KeyStatusActive, Key: edPrivate},
+		{ID: "retired", Algorithm: "EdDSA", Status: KeyStatusRetired, Key: retiredPublic},
+	})
+
+	set := PublicJWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("PublicJWKS() has %d keys, want 2 (HS256 and retired keys must be excluded): %+v", len(set.Keys), set.Keys)
+	}
+	rsaJWK, edJWK := set.Keys[0], set.Keys[1]
+	if rsaJWK.KeyID != "rsa" || rsaJWK.KeyType != "RSA" || rsaJWK.Algorithm != "RS256" || rsaJWK.Use != "sig" {
+		t.Errorf("RSA key = %+v", rsaJWK)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	if err != nil || new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 {
+		t.Errorf("RSA n = %q does not match the public key", rsaJWK.N)
+	}
+	if rsaJWK.E != "AQAB" {
+		t.Errorf("RSA e = %q, want AQAB", rsaJWK.E)
+	}
+	if edJWK.KeyID != "ed" || edJWK.KeyType != "OKP" || edJWK.Curve != "Ed25519" || edJWK.Algorithm != "EdDSA" {
+		t.Errorf("Ed25519 key = %+v", edJWK)
+	}
+	if x, err := base64.RawURLEncoding.DecodeString(edJWK.X); err != nil || !edKey.Equal(ed25519.PublicKey(x)) {
+		t.Errorf("Ed25519 x = %q does not match the public key", edJWK.X)
+	}
+}
+
+func TestNewKeyRingRejects(t *testing.T) {
+	rsaPrivate, rsaPublic, _ := rsaKeyPEM(t, 2048)
+	weakRSA, _, _ := rsaKeyPEM(t, 1024)
+	edPrivate, _, _ := ed25519KeyPEM(t)
+	tests := []struct {
+		name    string
+		configs []keyConfig
+		wantErr string
+	}{
+		{"activeな鍵がない", []keyConfig{{ID: "a", Algorithm: "HS256", Status: KeyStatusVerify, Key: testHMACKey}}, "activeな鍵を1つ指定してください"},
+		{"activeな鍵が複数ある", []keyConfig{
+			{ID: "a", Algorithm: "HS256", Status: KeyStatusActive, Key: testHMACKey},
+			{ID: "b", Algorithm: "EdDSA", Status: KeyStatusActive, Key: edPrivate},
+		}, "activeな鍵は1つだけ指定してください"},
+		{"activeな鍵が公開鍵のみ", []keyConfig{{ID: "a", Algorithm: "RS256", Status: KeyStatusActive, Key: rsaPublic}}, "秘密鍵を指定してください"},
+		{"鍵IDが重複している", []keyConfig{
+			{ID: "a", Algorithm: "HS256", Status: KeyStatusActive, Key: testHMACKey},
+			{ID: "a", Algorithm: "RS256", Status: KeyStatusVerify, Key: rsaPrivate},
+		}, "重複しています"},
+		{"kidがない", []keyConfig{{Algorithm: "HS256", Status: KeyStatusActive, Key: testHMACKey}}, "kidを指定してください"},
+		{"鍵がない", []keyConfig{{ID: "a", Algorithm: "HS256", Status: KeyStatusActive}}, "keyまたはkey_fileを指定してください"},
+		{"statusが不正", []keyConfig{{ID: "a", Algorithm: "HS256", Status: "current", Key: testHMACKey}}, "statusには"},
+		{"algが不正", []keyConfig{{ID: "a", Algorithm: "ES256", Status: KeyStatusActive, Key: testHMACKey}}, "algには"},
+		{"HS256の鍵が短い", []keyConfig{{ID: "a", Algorithm: "HS256", Status: KeyStatusActive, Key: "short"}}, "バイト以上"},
+		{"RS256の鍵が短い", []keyConfig{{ID: "a", Algorithm: "RS256", Status: KeyStatusActive, Key: weakRSA}}, "ビット以上"},
+		{"RS256にEd25519の鍵を指定した", []keyConfig{{ID: "a", Algorithm: "RS256", Status: KeyStatusActive, Key: edPrivate}}, "RSA秘密鍵または公開鍵"},
+		{"EdDSAにRSAの鍵を指定した", []keyConfig{{ID: "a", Algorithm: "EdDSA", Status: KeyStatusActive, Key: rsaPrivate}}, "Ed25519秘密鍵または公開鍵"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeyRing(tt.configs)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("newKeyRing() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package utility
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// LegacyPepperID はJWT_SECRET_KEYを連結する旧方式でハッシュ化されたパスワードのペッパーIDです
+// PASSWORD_PEPPER導入前に登録されたユーザーはこのIDを持ち、次回のログイン時に現在のペッパーで再ハッシュ化されます
+const LegacyPepperID = ""
+
+// developmentPepper はPASSWORD_PEPPERが設定されていない場合に使用する開発用のペッパーです
+const developmentPepper = "dev:fallback_development_pepper_do_not_use_in_production"
+
+// pepperIDPattern はペッパーのキーIDとして使用できる文字列です
+var pepperIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// currentPepperID は新しくハッシュ化する際に使用するペッパーのキーIDです
+var currentPepperID string
+
+// peppers はキーIDごとのペッパーです。ローテーション前のペッパーも検証のために保持します
+var peppers map[string][]byte
+
+// passwordHasher は新しくハッシュ化する際に使用するハッシュ化方式です
+var passwordHasher PasswordHasher
+
+// passwordHashers はアルゴリズム名ごとのハッシュ化方式です。設定と異なるアルゴリズムのハッシュの検証に使用します
+var passwordHashers map[string]PasswordHasher
+
+func init() {
+	value := os.Getenv("PASSWORD_PEPPER")
+	if value == "" {
+		fmt.Println("警告: PASSWORD_PEPPERが設定されていません。開発用のペッパーを使用します。")
+		value = developmentPepper
+	}
+	var err error
+	currentPepperID, peppers, err = parsePeppers(value)
+	if err != nil {
+		fmt.Printf("エラー: PASSWORD_PEPPERの値が無効です: %v\n", err)
+		os.Exit(1)
+	}
+	bcryptHasher := NewBcryptHasher(intFromEnv("BCRYPT_COST_FACTOR", 12, bcrypt.MinCost, bcrypt.MaxCost))
+	argon2idHasher := NewArgon2idHasher(
+		uint32(intFromEnv("ARGON2_MEMORY", 64*1024, 8*1024, 4*1024*1024)),
+		uint32(intFromEnv("ARGON2_ITERATIONS", 3, 1, 100)),
+		uint8(intFromEnv("ARGON2_PARALLELISM", 2, 1, 255)),
+	)
+	passwordHashers = map[string]PasswordHasher{
+		AlgorithmBcrypt:   bcryptHasher,
+		AlgorithmArgon2id: argon2idHasher,
+	}
+	algorithm := GetEnv("PASSWORD_HASH_ALGORITHM", AlgorithmBcrypt)
+	hasher, ok := passwordHashers[algorithm]
+	if !ok {
+		fmt.Println("警告: PASSWORD_HASH_ALGORITHMの値が無効です。bcryptを使用します。")
+		hasher = bcryptHasher
+	}
+	passwordHasher = hasher
+}
+
+// parsePeppers は「キーID:ペッパー」をカンマ区切りで並べた値を解析します
+// 先頭のペッパーを新しいハッシュに使用し、残りは既存のハッシュの検証にのみ使用します
+func parsePeppers(value string) (string, map[string][]byte, error) {
+	var currentID string
+	parsed := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || secret == "" {
+			return "", nil, errors.New("「キーID:ペッパー」の形式で指定してください")
+		}
+		if !pepperIDPattern.MatchString(id) {
+			return "", nil, fmt.Errorf("キーID %q は英数字、_、-の32文字以内で指定してください", id)
+		}
+		if _, exists := parsed[id]; exists {
+			return "", nil, fmt.Errorf("キーID %q が重複しています", id)
+		}
+		if currentID == "" {
+			currentID = id
+		}
+		parsed[id] = []byte(secret)
+	}
+
+	return currentID, parsed, nil
+}
+
+// pepperPassword はキーIDに対応するペッパーをパスワードに適用します
+// HMAC-SHA256の結果をBase64にするため、bcryptの72バイトの制限にペッパーが切り捨てられることはありません
+func pepperPassword(password, pepperID string) ([]byte, error) {
+	if pepperID == LegacyPepperID {
+		return []byte(password + string(jwtSecretKey)), nil
+	}
+	secret, ok := peppers[pepperID]
+	if !ok {
+		return nil, fmt.Errorf("ペッパーのキーID %q が設定されていません", pepperID)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(password))
+
+	return []byte(base64.RawStdEncoding.EncodeToString(mac.Sum(nil))), nil
+}
+
+// HashPassword は現在のペッパーを適用したパスワードを設定されたハッシュ化方式でハッシュ化し、ハッシュとペッパーのキーIDを返します
+func HashPassword(password string) (string, string, error) {
+	peppered, err := pepperPassword(password, currentPepperID)
+	if err != nil {
+		return "", "", err
+	}
+	hashedPassword, err := passwordHasher.Hash(peppered)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hashedPassword, currentPepperID, nil
+}
+
+// VerifyPassword はハッシュ化の際に使用したペッパーとアルゴリズムでパスワードとハッシュを比較します
+func VerifyPassword(hashedPassword, password, pepperID string) error {
+	hasher, ok := passwordHashers[hashAlgorithm(hashedPassword)]
+	if !ok {
+		return errInvalidPasswordHash
+	}
+	peppered, err := pepperPassword(password, pepperID)
+	if err != nil {
+		return err
+	}
+
+	return hasher.Verify(hashedPassword, peppered)
+}
+
+// CheckPasswordHash はパスワードとハッシュが一致するか検証します
+func CheckPasswordHash(password, hash, pepperID string) bool {
+	return VerifyPassword(hash, password, pepperID) == nil
+}
+
+// PasswordNeedsRehash はパスワードを現在の設定で再ハッシュ化すべきかを返します
+// ペッパーやアルゴリズムが現在の設定と異なる場合や、ハッシュのパラメータが現在の設定より弱い場合に再ハッシュ化します
+func PasswordNeedsRehash(hashedPassword, pepperID string) bool {
+	return pepperID != currentPepperID ||
+		hashAlgorithm(hashedPassword) != passwordHasher.Algorithm() ||
+		passwordHasher.NeedsRehash(hashedPassword)
+}
@@ -0,0 +1,216 @@
+package utility
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// AlgorithmBcrypt はbcryptを表すアルゴリズム名です
+	AlgorithmBcrypt = "bcrypt"
+	// AlgorithmArgon2id はArgon2idを表すアルゴリズム名です
+	AlgorithmArgon2id = "argon2id"
+)
+
+var (
+	errPasswordMismatch    = errors.New("パスワードが一致しません")
+	errInvalidPasswordHash = errors.New("パスワードハッシュの形式が無効です")
+)
+
+// PasswordHasher はパスワードのハッシュ化方式です
+// ハッシュはアルゴリズムとパラメータを含むPHC形式の文字列で表すため、設定を変更しても既存のハッシュを検証できます
+type PasswordHasher interface {
+	// Algorithm はアルゴリズム名を返します
+	Algorithm() string
+	// Hash はパスワードをハッシュ化します
+	Hash(password []byte) (string, error)
+	// Verify はパスワードとハッシュが一致するか検証します。パラメータはハッシュに記録された値を使用します
+	Verify(encoded string, password []byte) error
+	// NeedsRehash はハッシュのパラメータが現在の設定より弱いかどうかを返します
+	NeedsRehash(encoded string) bool
+}
+
+// BcryptHasher はbcryptでパスワードをハッシュ化します
+// ハッシュはbcrypt標準の形式（$2a$コスト$ソルトとハッシュ）で、PHC形式と互換性があります
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher は新しいBcryptHasherのインスタンスを作成します
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{
+		Cost: cost,
+	}
+}
+
+// Algorithm はアルゴリズム名を返します
+func (h *BcryptHasher) Algorithm() string {
+	return AlgorithmBcrypt
+}
+
+// Hash はパスワードをbcryptでハッシュ化します
+func (h *BcryptHasher) Hash(password []byte) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword(password, h.Cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashedBytes), nil
+}
+
+// Verify はパスワードとbcryptのハッシュが一致するか検証します
+func (h *BcryptHasher) Verify(encoded string, password []byte) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), password)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return errPasswordMismatch
+	}
+
+	return err
+}
+
+// NeedsRehash はハッシュのコストが現在の設定より小さいかどうかを返します
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost < h.Cost
+}
+
+// Argon2idHasher はArgon2idでパスワードをハッシュ化します
+// ハッシュは$argon2id$v=19$m=メモリ,t=反復回数,p=並列数$ソルト$ハッシュのPHC形式です
+type Argon2idHasher struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// NewArgon2idHasher は新しいArgon2idHasherのインスタンスを作成します
+// memoryはKiB単位で指定します
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	return &Argon2idHasher{
+		Memory:      memory,
+		Iterations:  iterations,
+		Parallelism: parallelism,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// Algorithm はアルゴリズム名を返します
+func (h *Argon2idHasher) Algorithm() string {
+	return AlgorithmArgon2id
+}
+
+// Hash はランダムなソルトを生成し、パスワードをArgon2idでハッシュ化します
+func (h *Argon2idHasher) Hash(password []byte) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(password, salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return encodeArgon2id(argon2Params{
+		version:     argon2.Version,
+		memory:      h.Memory,
+		iterations:  h.Iterations,
+		parallelism: h.Parallelism,
+		salt:        salt,
+		key:         key,
+	}), nil
+}
+
+// Verify はハッシュに記録されたパラメータでパスワードをハッシュ化し、一致するか検証します
+func (h *Argon2idHasher) Verify(encoded string, password []byte) error {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return err
+	}
+	key := argon2.IDKey(password, params.salt, params.iterations, params.memory, params.parallelism, uint32(len(params.key)))
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return errPasswordMismatch
+	}
+
+	return nil
+}
+
+// NeedsRehash はハッシュのいずれかのパラメータが現在の設定より弱いかどうかを返します
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	return params.version != argon2.Version ||
+		params.memory < h.Memory ||
+		params.iterations < h.Iterations ||
+		params.parallelism < h.Parallelism ||
+		uint32(len(params.salt)) < h.SaltLength ||
+		uint32(len(params.key)) < h.KeyLength
+}
+
+// argon2Params はArgon2idのハッシュに記録されたパラメータです
+type argon2Params struct {
+	version     int
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+// encodeArgon2id はArgon2idのパラメータとハッシュをPHC形式の文字列にします
+func encodeArgon2id(params argon2Params) string {
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id,
+		params.version,
+		params.memory,
+		params.iterations,
+		params.parallelism,
+		base64.RawStdEncoding.EncodeToString(params.salt),
+		base64.RawStdEncoding.EncodeToString(params.key),
+	)
+}
+
+// decodeArgon2id はPHC形式の文字列からArgon2idのパラメータとハッシュを取り出します
+func decodeArgon2id(encoded string) (*argon2Params, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != AlgorithmArgon2id {
+		return nil, errInvalidPasswordHash
+	}
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &params.version); err != nil {
+		return nil, errInvalidPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, errInvalidPasswordHash
+	}
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errInvalidPasswordHash
+	}
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(params.key) == 0 {
+		return nil, errInvalidPasswordHash
+	}
+
+	return &params, nil
+}
+
+// hashAlgorithm はハッシュの先頭に記録されたアルゴリズム名を返します
+// bcryptの$2a$、$2b$、$2y$はいずれもbcryptとして扱います
+func hashAlgorithm(encoded string) string {
+	id, _, _ := strings.Cut(strings.TrimPrefix(encoded, "$"), "$")
+	switch id {
+	case "2a", "2b", "2y":
+		return AlgorithmBcrypt
+	default:
+		return id
+	}
+}
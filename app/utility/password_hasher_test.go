@@ -0,0 +1,194 @@
+package utility
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// テストで使用するArgon2idの最小限のパラメータです
+const (
+	testArgon2Memory      = 8 * 1024
+	testArgon2Iterations  = 1
+	testArgon2Parallelism = 1
+)
+
+func TestPasswordHasherVerify(t *testing.T) {
+	hashers := []PasswordHasher{
+		NewBcryptHasher(bcrypt.MinCost),
+		NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism),
+	}
+	for _, hasher := range hashers {
+		t.Run(hasher.Algorithm(), func(t *testing.T) {
+			encoded, err := hasher.Hash([]byte("correct-horse"))
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if got := hashAlgorithm(encoded); got != hasher.Algorithm() {
+				t.Errorf("hashAlgorithm(%q) = %q, want %q", encoded, got, hasher.Algorithm())
+			}
+			if err := hasher.Verify(encoded, []byte("correct-horse")); err != nil {
+				t.Errorf("Verify() with the right password error = %v", err)
+			}
+			if err := hasher.Verify(encoded, []byte("wrong-horse")); !errors.Is(err, errPasswordMismatch) {
+				t.Errorf("Verify() with a wrong password error = %v, want errPasswordMismatch", err)
+			}
+			if hasher.NeedsRehash(encoded) {
+				t.Error("NeedsRehash() = true for a hash with the current parameters")
+			}
+		})
+	}
+}
+
+func TestArgon2idHashUsesRandomSalt(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism)
+	first, err := hasher.Hash([]byte("correct-horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := hasher.Hash([]byte("correct-horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Error("two hashes of the same password are identical")
+	}
+	if !strings.HasPrefix(first, "$argon2id$v=19$m=8192,t=1,p=1$") {
+		t.Errorf("Hash() = %q, want the PHC format with the parameters", first)
+	}
+}
+
+func TestBcryptNeedsRehash(t *testing.T) {
+	encoded, err := NewBcryptHasher(bcrypt.MinCost).Hash([]byte("correct-horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		cost    int
+		encoded string
+		want    bool
+	}{
+		{"コストが同じ", bcrypt.MinCost, encoded, false},
+		{"コストが現在の設定より小さい", bcrypt.MinCost + 1, encoded, true},
+		{"コストが現在の設定より大きい", bcrypt.MinCost - 1, encoded, false},
+		{"bcryptの形式でない", bcrypt.MinCost, "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA$a2V5", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBcryptHasher(tt.cost).NeedsRehash(tt.encoded); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	encoded, err := NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism).Hash([]byte("correct-horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		hasher *Argon2idHasher
+		want   bool
+	}{
+		{"パラメータが同じ", NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism), false},
+		{"メモリが増えた", NewArgon2idHasher(2*testArgon2Memory, testArgon2Iterations, testArgon2Parallelism), true},
+		{"反復回数が増えた", NewArgon2idHasher(testArgon2Memory, testArgon2Iterations+1, testArgon2Parallelism), true},
+		{"並列数が増えた", NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism+1), true},
+		{"ソルトが長くなった", &Argon2idHasher{Memory: testArgon2Memory, Iterations: testArgon2Iterations, Parallelism: testArgon2Parallelism, SaltLength: 32, KeyLength: 32}, true},
+		{"鍵が長くなった", &Argon2idHasher{Memory: testArgon2Memory, Iterations: testArgon2Iterations, Parallelism: testArgon2Parallelism, SaltLength: 16, KeyLength: 64}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(encoded); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idVerifyRejectsMalformedHash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2Memory, testArgon2Iterations, testArgon2Parallelism)
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"要素が足りない", "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA"},
+		{"別のアルゴリズム", "$argon2i$v=19$m=8192,t=1,p=1$c2FsdA$a2V5"},
+		{"パラメータの形式が不正", "$argon2id$v=19$memory=8192$c2FsdA$a2V5"},
+		{"ソルトがBase64でない", "$argon2id$v=19$m=8192,t=1,p=1$!!!$a2V5"},
+		{"ハッシュが空", "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := hasher.Verify(tt.encoded, []byte("correct-horse")); !errors.Is(err, errInvalidPasswordHash) {
+				t.Errorf("Verify() error = %v, want errInvalidPasswordHash", err)
+			}
+			if !hasher.NeedsRehash(tt.encoded) {
+				t.Error("NeedsRehash() = false for a malformed hash")
+			}
+		})
+	}
+}
+
+func TestHashAlgorithm(t *testing.T) {
+	tests := []struct {
+		encoded string
+		want    string
+	}{
+		{"$2a$04$abcdefghijklmnopqrstuu", AlgorithmBcrypt},
+		{"$2b$04$abcdefghijklmnopqrstuu", AlgorithmBcrypt},
+		{"$2y$04$abcdefghijklmnopqrstuu", AlgorithmBcrypt},
+		{"$argon2id$v=19$m=8192,t=1,p=1$c2FsdA$a2V5", AlgorithmArgon2id},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := hashAlgorithm(tt.encoded); got != tt.want {
+			t.Errorf("hashAlgorithm(%q) = %q, want %q", tt.encoded, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	peppered, err := pepperPassword("correct-horse", currentPepperID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := passwordHasher.Hash(peppered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := passwordHashers[AlgorithmArgon2id]
+	if passwordHasher.Algorithm() == AlgorithmArgon2id {
+		other = passwordHashers[AlgorithmBcrypt]
+	}
+	otherAlgorithm, err := other.Hash(peppered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		encoded  string
+		pepperID string
+		want     bool
+	}{
+		{"現在のペッパーとアルゴリズム", current, currentPepperID, false},
+		{"ローテーション前のペッパー", current, "old", true},
+		{"旧方式のペッパー", current, LegacyPepperID, true},
+		{"設定と異なるアルゴリズム", otherAlgorithm, currentPepperID, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PasswordNeedsRehash(tt.encoded, tt.pepperID); got != tt.want {
+				t.Errorf("PasswordNeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !CheckPasswordHash("correct-horse", otherAlgorithm, currentPepperID) {
+		t.Error("CheckPasswordHash() = false for a hash with a non-default algorithm")
+	}
+}
@@ -0,0 +1,633 @@
+// Package rrule はRFC 5545のRRULE（繰り返しルール）の一部を解析・評価します
+//
+// 対応している要素は FREQ（DAILY/WEEKLY/MONTHLY/YEARLY）、INTERVAL、BYDAY、
+// BYMONTHDAY、COUNT、UNTIL、WKST です。
+// 発生日時は基準日時（DTSTART）のタイムゾーンにおける壁時計の時刻で計算するため、
+// 夏時間の切り替えをまたいでも同じ時刻に発生します。
+package rrule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPeriods は発生日時を探索する期間（日・週・月・年）の上限です
+// 発生し得ないルールで無限ループにならないようにするためのものです
+const maxPeriods = 100000
+
+// グレゴリオ暦は400年周期で曜日と日付の並びが一巡します
+// 一巡する間に一度も発生しない場合は、それ以降も発生しません
+const (
+	daysPerCycle   = 146097
+	weeksPerCycle  = daysPerCycle / 7
+	monthsPerCycle = 400 * 12
+	yearsPerCycle  = 400
+)
+
+// validationYears はBYDAYとBYMONTHDAYの組み合わせが一致し得るかを検証する期間です
+// 28年間には閏年かどうかと元日の曜日の全ての組み合わせが含まれます
+const (
+	validationStartYear = 2000
+	validationYears     = 28
+)
+
+// ErrorReason はルールを解析できなかった理由です
+type ErrorReason int
+
+const (
+	// ReasonEmpty はルールが空であることを表します
+	ReasonEmpty ErrorReason = iota
+	// ReasonMalformed は要素が「名前=値」の形式でないことを表します。Valueは不正な要素です
+	ReasonMalformed
+	// ReasonDuplicatePart は要素が重複していることを表します。Valueは要素名です
+	ReasonDuplicatePart
+	// ReasonUnsupportedPart はサポートされていない要素であることを表します。Valueは要素名です
+	ReasonUnsupportedPart
+	// ReasonFreqRequired はFREQが指定されていないことを表します
+	ReasonFreqRequired
+	// ReasonUnsupportedFreq はサポートされていないFREQであることを表します。Valueは指定された値です
+	ReasonUnsupportedFreq
+	// ReasonNotPositive はINTERVALまたはCOUNTが1以上の整数でないことを表します。Valueは要素名です
+	ReasonNotPositive
+	// ReasonInvalidUntil はUNTILの形式が不正であることを表します。Valueは指定された値です
+	ReasonInvalidUntil
+	// ReasonInvalidByDay はBYDAYの形式が不正であることを表します。Valueは不正な項目です
+	ReasonInvalidByDay
+	// ReasonInvalidOrdinal はBYDAYの序数が0または-5から5の範囲外であることを表します。Valueは不正な項目です
+	ReasonInvalidOrdinal
+	// ReasonInvalidMonthDay はBYMONTHDAYが-31から31（0を除く）の範囲外であることを表します。Valueは不正な項目です
+	ReasonInvalidMonthDay
+	// ReasonInvalidWeekday は曜日の形式が不正であることを表します。Valueは指定された値です
+	ReasonInvalidWeekday
+	// ReasonCountWithUntil はCOUNTとUNTILが同時に指定されたことを表します
+	ReasonCountWithUntil
+	// ReasonOrdinalRequiresMonthly は序数付きのBYDAYがFREQ=MONTHLY以外で指定されたことを表します。Valueは序数付きの項目です
+	ReasonOrdinalRequiresMonthly
+	// ReasonYearlyByDay はFREQ=YEARLYでBYDAYが指定されたことを表します
+	ReasonYearlyByDay
+	// ReasonNeverMatches はBYDAYとBYMONTHDAYの両方に一致する日付がないことを表します
+	ReasonNeverMatches
+)
+
+var reasonMessages = map[ErrorReason]string{
+	ReasonEmpty:                  "繰り返しルールが空です",
+	ReasonMalformed:              "繰り返しルールの形式が不正です",
+	ReasonDuplicatePart:          "要素が重複しています",
+	ReasonUnsupportedPart:        "サポートされていない要素です",
+	ReasonFreqRequired:           "FREQは必須です",
+	ReasonUnsupportedFreq:        "サポートされていないFREQです",
+	ReasonNotPositive:            "1以上の整数を指定してください",
+	ReasonInvalidUntil:           "UNTILの形式が不正です",
+	ReasonInvalidByDay:           "BYDAYの形式が不正です",
+	ReasonInvalidOrdinal:         "BYDAYの序数が不正です",
+	ReasonInvalidMonthDay:        "BYMONTHDAYには-31から31（0を除く）を指定してください",
+	ReasonInvalidWeekday:         "曜日の形式が不正です",
+	ReasonCountWithUntil:         "COUNTとUNTILは同時に指定できません",
+	ReasonOrdinalRequiresMonthly: "序数付きのBYDAYはFREQ=MONTHLYでのみ指定できます",
+	ReasonYearlyByDay:            "FREQ=YEARLYではBYDAYはサポートされていません",
+	ReasonNeverMatches:           "BYDAYとBYMONTHDAYに一致する日付がありません",
+}
+
+// ParseError はルールを解析できなかったことを表すエラーです
+// 呼び出し側で理由ごとにメッセージを翻訳できるよう、理由と原因となった値を保持します
+type ParseError struct {
+	Reason ErrorReason
+	Value  string
+}
+
+// Error は解析できなかった理由を返します
+func (e *ParseError) Error() string {
+	if e.Value == "" {
+		return reasonMessages[e.Reason]
+	}
+
+	return reasonMessages[e.Reason] + ": " + e.Value
+}
+
+// Frequency は繰り返しの頻度です
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+// String は頻度のRRULE表記を返します
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// WeekdayNum はBYDAYの要素です
+// Ordinalが0以外の場合は月内の第N（負の値は最後から第N）の曜日を表します
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// String はBYDAY要素のRRULE表記を返します
+func (w WeekdayNum) String() string {
+	if w.Ordinal == 0 {
+		return weekdayNames[w.Weekday]
+	}
+
+	return strconv.Itoa(w.Ordinal) + weekdayNames[w.Weekday]
+}
+
+// Rule は解析済みの繰り返しルールです
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	WeekStart  time.Weekday
+
+	until         time.Time
+	hasUntil      bool
+	untilFloating bool
+	untilDateOnly bool
+	untilValue    string
+}
+
+// Parse はRRULE文字列を解析します
+// 先頭の "RRULE:" は省略できます
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+	if value == "" {
+		return nil, &ParseError{Reason: ReasonEmpty}
+	}
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	hasFreq := false
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, &ParseError{Reason: ReasonMalformed, Value: part}
+		}
+		if seen[key] {
+			return nil, &ParseError{Reason: ReasonDuplicatePart, Value: key}
+		}
+		seen[key] = true
+		var err error
+		switch key {
+		case "FREQ":
+			err = rule.parseFreq(val)
+			hasFreq = true
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(key, val)
+		case "COUNT":
+			rule.Count, err = parsePositive(key, val)
+		case "UNTIL":
+			err = rule.parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		case "WKST":
+			rule.WeekStart, err = parseWeekday(val)
+		default:
+			err = &ParseError{Reason: ReasonUnsupportedPart, Value: key}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !hasFreq {
+		return nil, &ParseError{Reason: ReasonFreqRequired}
+	}
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// String はルールを正規化したRRULE文字列を返します
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.hasUntil {
+		parts = append(parts, "UNTIL="+r.untilValue)
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next は基準日時dtstartから始まる繰り返しのうち、afterより後の最初の発生日時を返します
+// 以降の発生がない場合はfalseを返します
+func (r *Rule) Next(dtstart time.Time, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			found = true
+			return false
+		}
+		return true
+	})
+
+	return next, found
+}
+
+// All は基準日時dtstartから始まる繰り返しの発生日時を最大limit件返します
+func (r *Rule) All(dtstart time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	if limit <= 0 {
+		return occurrences
+	}
+	r.iterate(dtstart, func(t time.Time) bool {
+		occurrences = append(occurrences, t)
+		return len(occurrences) < limit
+	})
+
+	return occurrences
+}
+
+// iterate は発生日時を古い順にyieldへ渡します
+// 基準日時より前の候補は含めず、基準日時自体もルールに一致する場合のみ含めます
+func (r *Rule) iterate(dtstart time.Time, yield func(time.Time) bool) {
+	until := r.untilIn(dtstart.Location())
+	count := 0
+	cycle := r.periodsPerCycle()
+	lastMatched := 0
+	for period := 0; period < maxPeriods; period++ {
+		if period-lastMatched > cycle {
+			return
+		}
+		for _, t := range r.candidates(dtstart, period) {
+			lastMatched = period
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.hasUntil && t.After(until) {
+				return
+			}
+			count++
+			if !yield(t) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates はperiod番目の期間に含まれる発生日時の候補を古い順に返します
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	year, month, day := dtstart.Date()
+	step := period * r.Interval
+	var dates []time.Time
+	switch r.Freq {
+	case Daily:
+		date := civilDate(year, month, day+step)
+		if r.matchesWeekday(date) && r.matchesMonthDay(date) {
+			dates = append(dates, date)
+		}
+	case Weekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := civilDate(year, month, day-offset+7*step)
+		weekdays := r.ByDay
+		if len(weekdays) == 0 {
+			weekdays = []WeekdayNum{{Weekday: dtstart.Weekday()}}
+		}
+		for i := 0; i < 7; i++ {
+			date := weekStart.AddDate(0, 0, i)
+			for _, weekday := range weekdays {
+				if date.Weekday() == weekday.Weekday && r.matchesMonthDay(date) {
+					dates = append(dates, date)
+					break
+				}
+			}
+		}
+	case Monthly:
+		first := civilDate(year, month+time.Month(step), 1)
+		dates = r.daysInMonth(first, day)
+	case Yearly:
+		// BYMONTHDAYを指定した場合は年内の全ての月が対象になります（RFC 5545のBYMONTHを省略した場合と同じです）
+		if len(r.ByMonthDay) == 0 {
+			dates = r.daysInMonth(civilDate(year+step, month, 1), day)
+			break
+		}
+		for m := time.January; m <= time.December; m++ {
+			dates = append(dates, r.daysInMonth(civilDate(year+step, m, 1), day)...)
+		}
+	}
+	result := make([]time.Time, len(dates))
+	for i, date := range dates {
+		result[i] = wallClock(date, dtstart)
+	}
+
+	return result
+}
+
+// periodsPerCycle は暦が一巡するまでの期間の数を返します
+// INTERVALごとに進むため、周期とINTERVALの最大公約数で割った数の期間で全ての組み合わせを一巡します
+func (r *Rule) periodsPerCycle() int {
+	cycle := daysPerCycle
+	switch r.Freq {
+	case Weekly:
+		cycle = weeksPerCycle
+	case Monthly:
+		cycle = monthsPerCycle
+	case Yearly:
+		cycle = yearsPerCycle
+	}
+
+	return cycle / gcd(cycle, r.Interval)
+}
+
+// daysInMonth はfirstで表される月のうちルールに一致する日付を返します
+// BYMONTHDAYもBYDAYも指定されていない場合は基準日と同じ日を返し、その日が存在しない月は対象外です
+func (r *Rule) daysInMonth(first time.Time, defaultDay int) []time.Time {
+	year, month, _ := first.Date()
+	lastDay := daysIn(year, month)
+	var days []int
+	switch {
+	case len(r.ByMonthDay) > 0 || len(r.ByDay) > 0:
+		for day := 1; day <= lastDay; day++ {
+			date := civilDate(year, month, day)
+			if r.matchesMonthDay(date) && r.matchesWeekday(date) {
+				days = append(days, day)
+			}
+		}
+	case defaultDay <= lastDay:
+		days = append(days, defaultDay)
+	}
+	dates := make([]time.Time, len(days))
+	for i, day := range days {
+		dates[i] = civilDate(year, month, day)
+	}
+
+	return dates
+}
+
+// matchesWeekday は日付がBYDAYに一致するかを返します
+// 序数付きの曜日は月内の第N曜日として判定します
+func (r *Rule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	year, month, day := date.Date()
+	for _, weekday := range r.ByDay {
+		if date.Weekday() != weekday.Weekday {
+			continue
+		}
+		switch {
+		case weekday.Ordinal == 0:
+			return true
+		case weekday.Ordinal > 0 && (day-1)/7+1 == weekday.Ordinal:
+			return true
+		case weekday.Ordinal < 0 && (daysIn(year, month)-day)/7+1 == -weekday.Ordinal:
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesMonthDay は日付がBYMONTHDAYに一致するかを返します
+// 負の値は月末から数えた日として判定します
+func (r *Rule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	year, month, day := date.Date()
+	lastDay := daysIn(year, month)
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == day || (monthDay < 0 && lastDay+monthDay+1 == day) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// untilIn はUNTILを指定されたタイムゾーンの日時として返します
+// 日付のみのUNTILはその日の終わりまでを含みます
+func (r *Rule) untilIn(loc *time.Location) time.Time {
+	if !r.hasUntil || !r.untilFloating {
+		return r.until
+	}
+	year, month, day := r.until.Date()
+	hour, minute, second := r.until.Clock()
+	if r.untilDateOnly {
+		return time.Date(year, month, day+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	}
+
+	return time.Date(year, month, day, hour, minute, second, 0, loc)
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for freq, name := range frequencyNames {
+		if name == value {
+			r.Freq = freq
+			return nil
+		}
+	}
+
+	return &ParseError{Reason: ReasonUnsupportedFreq, Value: value}
+}
+
+func (r *Rule) parseUntil(value string) error {
+	layouts := []struct {
+		layout   string
+		floating bool
+		dateOnly bool
+	}{
+		{"20060102T150405Z", false, false},
+		{"20060102T150405", true, false},
+		{"20060102", true, true},
+	}
+	for _, l := range layouts {
+		until, err := time.Parse(l.layout, value)
+		if err != nil {
+			continue
+		}
+		r.until = until
+		r.hasUntil = true
+		r.untilFloating = l.floating
+		r.untilDateOnly = l.dateOnly
+		r.untilValue = value
+		return nil
+	}
+
+	return &ParseError{Reason: ReasonInvalidUntil, Value: value}
+}
+
+// validate は要素の組み合わせを検証します
+func (r *Rule) validate() error {
+	if r.Count > 0 && r.hasUntil {
+		return &ParseError{Reason: ReasonCountWithUntil}
+	}
+	for _, weekday := range r.ByDay {
+		if weekday.Ordinal == 0 {
+			continue
+		}
+		if r.Freq != Monthly {
+			return &ParseError{Reason: ReasonOrdinalRequiresMonthly, Value: weekday.String()}
+		}
+		if weekday.Ordinal < -5 || weekday.Ordinal > 5 {
+			return &ParseError{Reason: ReasonInvalidOrdinal, Value: weekday.String()}
+		}
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 {
+		return &ParseError{Reason: ReasonYearlyByDay}
+	}
+	if !r.canMatch() {
+		return &ParseError{Reason: ReasonNeverMatches}
+	}
+
+	return nil
+}
+
+// canMatch はBYDAYとBYMONTHDAYの両方に一致する日付が存在するかを返します
+// 例えばBYMONTHDAY=1とBYDAY=5MOの組み合わせは発生しないため、解析時に拒否します
+func (r *Rule) canMatch() bool {
+	if len(r.ByDay) == 0 || len(r.ByMonthDay) == 0 {
+		return true
+	}
+	end := civilDate(validationStartYear+validationYears, time.January, 1)
+	for date := civilDate(validationStartYear, time.January, 1); date.Before(end); date = date.AddDate(0, 0, 1) {
+		if r.matchesWeekday(date) && r.matchesMonthDay(date) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePositive(key string, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, &ParseError{Reason: ReasonNotPositive, Value: key}
+	}
+
+	return n, nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var weekdays []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, &ParseError{Reason: ReasonInvalidByDay, Value: item}
+		}
+		weekday, err := parseWeekday(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err = strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 {
+				return nil, &ParseError{Reason: ReasonInvalidOrdinal, Value: item}
+			}
+		}
+		weekdays = append(weekdays, WeekdayNum{Ordinal: ordinal, Weekday: weekday})
+	}
+
+	return weekdays, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(item)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, &ParseError{Reason: ReasonInvalidMonthDay, Value: item}
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for weekday, name := range weekdayNames {
+		if name == value {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, &ParseError{Reason: ReasonInvalidWeekday, Value: value}
+}
+
+// civilDate は夏時間の影響を受けない暦上の日付を返します
+// 範囲外の日や月は正規化されます
+func civilDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// wallClock はdateの日付にdtstartと同じ壁時計の時刻を組み合わせた日時を返します
+// 夏時間の開始により存在しない時刻になる場合は、RFC 5545に従い切り替え前のオフセットで解釈します
+func wallClock(date time.Time, dtstart time.Time) time.Time {
+	year, month, day := date.Date()
+	hour, minute, second := dtstart.Clock()
+	loc := dtstart.Location()
+	t := time.Date(year, month, day, hour, minute, second, dtstart.Nanosecond(), loc)
+	if h, m, s := t.Clock(); h == hour && m == minute && s == second {
+		return t
+	}
+	wall := time.Date(year, month, day, hour, minute, second, dtstart.Nanosecond(), time.UTC)
+	_, offset := wall.Add(-24 * time.Hour).In(loc).Zone()
+
+	return wall.Add(-time.Duration(offset) * time.Second).In(loc)
+}
+
+// gcd は最大公約数を返します
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// daysIn は指定された月の日数を返します
+func daysIn(year int, month time.Month) int {
+	return civilDate(year, month+1, 0).Day()
+}
@@ -0,0 +1,392 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+const occurrenceLayout = "2006-01-02 15:04 MST"
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q): %v", name, err)
+	}
+	return loc
+}
+
+func formatOccurrences(occurrences []time.Time) []string {
+	result := make([]string, len(occurrences))
+	for i, occurrence := range occurrences {
+		result[i] = occurrence.Format(occurrenceLayout)
+	}
+	return result
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAll(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []string
+	}{
+		// 夏時間
+		{
+			name:    "毎日の発生は夏時間の開始をまたいでも同じ壁時計の時刻",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2024, 3, 9, 9, 0, 0, 0, newYork),
+			limit:   3,
+			want:    []string{"2024-03-09 09:00 EST", "2024-03-10 09:00 EDT", "2024-03-11 09:00 EDT"},
+		},
+		{
+			name:    "夏時間の開始で存在しない時刻は切り替え前のオフセットで解釈する",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2024, 3, 9, 2, 30, 0, 0, newYork),
+			limit:   3,
+			want:    []string{"2024-03-09 02:30 EST", "2024-03-10 03:30 EDT", "2024-03-11 02:30 EDT"},
+		},
+		{
+			name:    "夏時間の終了で重複する時刻は最初の発生を使う",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2024, 11, 2, 1, 30, 0, 0, newYork),
+			limit:   3,
+			want:    []string{"2024-11-02 01:30 EDT", "2024-11-03 01:30 EDT", "2024-11-04 01:30 EST"},
+		},
+		{
+			name:    "毎週の発生は夏時間の終了をまたいでも同じ壁時計の時刻",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2024, 10, 28, 10, 0, 0, 0, newYork),
+			limit:   2,
+			want:    []string{"2024-10-28 10:00 EDT", "2024-11-04 10:00 EST"},
+		},
+		// 月末
+		{
+			name:    "BYMONTHDAY=31は31日がない月を飛ばす",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   4,
+			want:    []string{"2024-01-31 09:00 UTC", "2024-03-31 09:00 UTC", "2024-05-31 09:00 UTC", "2024-07-31 09:00 UTC"},
+		},
+		{
+			name:    "BYMONTHDAY=-1は各月の末日",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   4,
+			want:    []string{"2024-01-31 09:00 UTC", "2024-02-29 09:00 UTC", "2024-03-31 09:00 UTC", "2024-04-30 09:00 UTC"},
+		},
+		{
+			name:    "BYMONTHDAY=-1は平年の2月では28日",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			want:    []string{"2023-01-31 09:00 UTC", "2023-02-28 09:00 UTC"},
+		},
+		{
+			name:    "BYMONTHDAYを省略した毎月の発生は基準日がない月を飛ばす",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-01-31 09:00 UTC", "2024-03-31 09:00 UTC", "2024-05-31 09:00 UTC"},
+		},
+		{
+			name:    "2月29日の毎年の発生は閏年のみ",
+			rule:    "FREQ=YEARLY",
+			dtstart: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-02-29 09:00 UTC", "2028-02-29 09:00 UTC", "2032-02-29 09:00 UTC"},
+		},
+		{
+			name:    "2月29日の毎年の発生は閏年でない2100年を飛ばす",
+			rule:    "FREQ=YEARLY",
+			dtstart: time.Date(2096, 2, 29, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			want:    []string{"2096-02-29 09:00 UTC", "2104-02-29 09:00 UTC"},
+		},
+		{
+			name:    "FREQ=YEARLYのBYMONTHDAYは年内の全ての月が対象",
+			rule:    "FREQ=YEARLY;BYMONTHDAY=1",
+			dtstart: time.Date(2024, 11, 1, 9, 0, 0, 0, time.UTC),
+			limit:   4,
+			want:    []string{"2024-11-01 09:00 UTC", "2024-12-01 09:00 UTC", "2025-01-01 09:00 UTC", "2025-02-01 09:00 UTC"},
+		},
+		{
+			name:    "FREQ=YEARLYのINTERVALは年単位で進む",
+			rule:    "FREQ=YEARLY;INTERVAL=2;BYMONTHDAY=-1",
+			dtstart: time.Date(2024, 11, 30, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-11-30 09:00 UTC", "2024-12-31 09:00 UTC", "2026-01-31 09:00 UTC"},
+		},
+		// 序数付きのBYDAY
+		{
+			name:    "BYDAY=-1FRは各月の最終金曜日",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-01-26 09:00 UTC", "2024-02-23 09:00 UTC", "2024-03-29 09:00 UTC"},
+		},
+		{
+			name:    "BYDAY=2TUは各月の第2火曜日",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-01-09 09:00 UTC", "2024-02-13 09:00 UTC", "2024-03-12 09:00 UTC"},
+		},
+		{
+			name:    "BYDAY=5MOは第5月曜日がある月のみ",
+			rule:    "FREQ=MONTHLY;BYDAY=5MO",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			want:    []string{"2024-01-29 09:00 UTC", "2024-04-29 09:00 UTC"},
+		},
+		{
+			name:    "BYDAYとBYMONTHDAYの両方に一致する日（13日の金曜日）",
+			rule:    "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    []string{"2024-09-13 09:00 UTC", "2024-12-13 09:00 UTC", "2025-06-13 09:00 UTC"},
+		},
+		// COUNTとUNTIL
+		{
+			name:    "COUNTは基準日時を含めた発生回数",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2024-01-01 09:00 UTC", "2024-01-03 09:00 UTC", "2024-01-05 09:00 UTC", "2024-01-08 09:00 UTC"},
+		},
+		{
+			name:    "COUNTは基準日時がルールに一致しない場合は数えない",
+			rule:    "FREQ=WEEKLY;BYDAY=FR;COUNT=2",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2024-01-05 09:00 UTC", "2024-01-12 09:00 UTC"},
+		},
+		{
+			name:    "UTCのUNTILはその日時を含む",
+			rule:    "FREQ=DAILY;UNTIL=20240103T090000Z",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want:    []string{"2024-01-01 09:00 UTC", "2024-01-02 09:00 UTC", "2024-01-03 09:00 UTC"},
+		},
+		{
+			name:    "UTCのUNTILは基準日時のタイムゾーンに関係なく絶対時刻で比較する",
+			rule:    "FREQ=DAILY;UNTIL=20240102T000000Z",
+			dtstart: time.Date(2024, 1, 1, 8, 0, 0, 0, tokyo),
+			limit:   10,
+			want:    []string{"2024-01-01 08:00 JST", "2024-01-02 08:00 JST"},
+		},
+		{
+			name:    "日付のみのUNTILは基準日時のタイムゾーンでその日の終わりまでを含む",
+			rule:    "FREQ=DAILY;UNTIL=20240103",
+			dtstart: time.Date(2024, 1, 1, 23, 0, 0, 0, tokyo),
+			limit:   10,
+			want:    []string{"2024-01-01 23:00 JST", "2024-01-02 23:00 JST", "2024-01-03 23:00 JST"},
+		},
+		{
+			name:    "タイムゾーンのないUNTILは基準日時のタイムゾーンの壁時計の時刻",
+			rule:    "FREQ=DAILY;UNTIL=20240103T080000",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, tokyo),
+			limit:   10,
+			want:    []string{"2024-01-01 09:00 JST", "2024-01-02 09:00 JST"},
+		},
+		// WKST（RFC 5545の例）
+		{
+			name:    "WKST=MOでは週の区切りが月曜日",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			dtstart: time.Date(1997, 8, 5, 9, 0, 0, 0, newYork),
+			limit:   10,
+			want:    []string{"1997-08-05 09:00 EDT", "1997-08-10 09:00 EDT", "1997-08-19 09:00 EDT", "1997-08-24 09:00 EDT"},
+		},
+		{
+			name:    "WKST=SUでは週の区切りが日曜日",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			dtstart: time.Date(1997, 8, 5, 9, 0, 0, 0, newYork),
+			limit:   10,
+			want:    []string{"1997-08-05 09:00 EDT", "1997-08-17 09:00 EDT", "1997-08-19 09:00 EDT", "1997-08-31 09:00 EDT"},
+		},
+		// 基準日時によって発生しないルール
+		{
+			name:    "12か月ごとの2月に30日はないため発生しない",
+			rule:    "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30",
+			dtstart: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+			limit:   1,
+			want:    []string{},
+		},
+		{
+			name:    "7日ごとの月曜日に金曜日は含まれないため発生しない",
+			rule:    "FREQ=DAILY;INTERVAL=7;BYDAY=FR",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   1,
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			got := formatOccurrences(rule.All(tt.dtstart, tt.limit))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("All() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		after   time.Time
+		want    string
+		wantOK  bool
+	}{
+		{
+			name:    "afterより後の最初の発生",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TH",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:    "2024-01-04 09:00 UTC",
+			wantOK:  true,
+		},
+		{
+			name:    "月末から翌月末",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			want:    "2024-02-29 09:00 UTC",
+			wantOK:  true,
+		},
+		{
+			name:    "COUNTに達した後は発生しない",
+			rule:    "FREQ=DAILY;COUNT=2",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+			wantOK:  false,
+		},
+		{
+			name:    "UNTILを過ぎた後は発生しない",
+			rule:    "FREQ=DAILY;UNTIL=20240102",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+			wantOK:  false,
+		},
+		{
+			name:    "FREQ=YEARLYは基準日の月以外にも発生する",
+			rule:    "FREQ=YEARLY;INTERVAL=4;BYMONTHDAY=31",
+			dtstart: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+			want:    "2024-03-31 09:00 UTC",
+			wantOK:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			got, ok := rule.Next(tt.dtstart, tt.after)
+			if ok != tt.wantOK {
+				t.Fatalf("Next() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Format(occurrenceLayout) != tt.want {
+				t.Errorf("Next() = %s, want %s", got.Format(occurrenceLayout), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		reason ErrorReason
+		value  string
+	}{
+		{"空", "", ReasonEmpty, ""},
+		{"RRULE:のみ", "RRULE:", ReasonEmpty, ""},
+		{"FREQがない", "INTERVAL=2", ReasonFreqRequired, ""},
+		{"サポートされていないFREQ", "FREQ=HOURLY", ReasonUnsupportedFreq, "HOURLY"},
+		{"値がない", "FREQ=DAILY;INTERVAL=", ReasonMalformed, "INTERVAL="},
+		{"区切りのみ", "FREQ=DAILY;", ReasonMalformed, ""},
+		{"要素の重複", "FREQ=DAILY;FREQ=WEEKLY", ReasonDuplicatePart, "FREQ"},
+		{"サポートされていない要素", "FREQ=MONTHLY;BYSETPOS=1", ReasonUnsupportedPart, "BYSETPOS"},
+		{"INTERVALが0", "FREQ=DAILY;INTERVAL=0", ReasonNotPositive, "INTERVAL"},
+		{"COUNTが負", "FREQ=DAILY;COUNT=-1", ReasonNotPositive, "COUNT"},
+		{"COUNTとUNTILの同時指定", "FREQ=DAILY;COUNT=2;UNTIL=20240101", ReasonCountWithUntil, ""},
+		{"UNTILの形式", "FREQ=DAILY;UNTIL=2024-01-01", ReasonInvalidUntil, "2024-01-01"},
+		{"不正な曜日", "FREQ=WEEKLY;BYDAY=XX", ReasonInvalidWeekday, "XX"},
+		{"序数が0", "FREQ=MONTHLY;BYDAY=0MO", ReasonInvalidOrdinal, "0MO"},
+		{"序数が範囲外", "FREQ=MONTHLY;BYDAY=6MO", ReasonInvalidOrdinal, "6MO"},
+		{"MONTHLY以外の序数", "FREQ=WEEKLY;BYDAY=1MO", ReasonOrdinalRequiresMonthly, "1MO"},
+		{"YEARLYのBYDAY", "FREQ=YEARLY;BYDAY=MO", ReasonYearlyByDay, ""},
+		{"BYMONTHDAYが0", "FREQ=MONTHLY;BYMONTHDAY=0", ReasonInvalidMonthDay, "0"},
+		{"BYMONTHDAYが範囲外", "FREQ=MONTHLY;BYMONTHDAY=32", ReasonInvalidMonthDay, "32"},
+		{"BYMONTHDAYが負の範囲外", "FREQ=MONTHLY;BYMONTHDAY=-32", ReasonInvalidMonthDay, "-32"},
+		{"不正なWKST", "FREQ=WEEKLY;WKST=XX", ReasonInvalidWeekday, "XX"},
+		{"1日と第5月曜日は一致しない", "FREQ=MONTHLY;BYMONTHDAY=1;BYDAY=5MO", ReasonNeverMatches, ""},
+		{"30日と31日は第1月曜日にならない", "FREQ=MONTHLY;BYMONTHDAY=30,31;BYDAY=1MO", ReasonNeverMatches, ""},
+		{"1日は最終金曜日にならない", "FREQ=MONTHLY;BYMONTHDAY=1,2,3;BYDAY=-1FR", ReasonNeverMatches, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %s, want error", tt.rule, rule)
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) error = %T, want *ParseError", tt.rule, err)
+			}
+			if parseErr.Reason != tt.reason || parseErr.Value != tt.value {
+				t.Errorf("Parse(%q) error = {%d %q}, want {%d %q}", tt.rule, parseErr.Reason, parseErr.Value, tt.reason, tt.value)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"RRULE:freq=weekly;byday=mo,fr;interval=1", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;INTERVAL=2", "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1,15;COUNT=5", "FREQ=MONTHLY;BYMONTHDAY=-1,15;COUNT=5"},
+		{"FREQ=DAILY;UNTIL=20240103T090000Z", "FREQ=DAILY;UNTIL=20240103T090000Z"},
+		{"FREQ=WEEKLY;WKST=SU", "FREQ=WEEKLY;WKST=SU"},
+		{"FREQ=WEEKLY;WKST=MO", "FREQ=WEEKLY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package utility
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateRandomToken は指定されたバイト数の乱数からURLセーフな文字列を生成します
+func GenerateRandomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken はトークンをSHA-256でハッシュ化し、16進数の文字列で返します
+// 十分な長さの乱数から生成したトークンの保存に使い、パスワードには使いません
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// PKCEChallenge はPKCE（RFC 7636）のS256方式で、code_verifierからcode_challengeを計算します
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
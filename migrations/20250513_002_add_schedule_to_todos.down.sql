@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_todos_user_id_due_at;
+
+ALTER TABLE todos
+	DROP COLUMN IF EXISTS due_at
+	,DROP COLUMN IF EXISTS start_at
+;
@@ -0,0 +1,6 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS start_at	timestamp with time zone
+	,ADD COLUMN IF NOT EXISTS due_at	timestamp with time zone
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_due_at ON todos(user_id, due_at);
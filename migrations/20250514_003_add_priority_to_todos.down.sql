@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_todos_user_id_priority;
+
+ALTER TABLE todos
+	DROP CONSTRAINT IF EXISTS ck_todos_priority
+	,DROP COLUMN IF EXISTS priority
+;
@@ -0,0 +1,7 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS priority	smallint			not null default 0
+	,ADD CONSTRAINT ck_todos_priority
+		CHECK (priority BETWEEN 0 AND 4)
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_priority ON todos(user_id, priority DESC, due_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_todo_tags_tag_id;
+
+DROP TABLE IF EXISTS todo_tags;
+DROP TABLE IF EXISTS tags;
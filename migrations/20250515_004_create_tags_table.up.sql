@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS tags (
+	id		serial 				primary key
+
+	,name		varchar(32) 			not null
+
+	,user_id	integer				not null
+
+	,created_at	timestamp with time zone	not null default current_timestamp
+	,updated_at	timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_tags_user_id_name
+		UNIQUE (user_id, name)
+	,CONSTRAINT fk_tags_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS todo_tags (
+	todo_id		integer				not null
+	,tag_id		integer				not null
+
+	,CONSTRAINT pk_todo_tags
+		PRIMARY KEY (todo_id, tag_id)
+	,CONSTRAINT fk_todo_tags_todo
+		FOREIGN KEY (todo_id)
+		REFERENCES todos(id)
+		ON DELETE CASCADE
+	,CONSTRAINT fk_todo_tags_tag
+		FOREIGN KEY (tag_id)
+		REFERENCES tags(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_tags_tag_id ON todo_tags(tag_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_todos_project_id;
+
+ALTER TABLE todos
+	DROP CONSTRAINT IF EXISTS fk_todos_project
+	,DROP COLUMN IF EXISTS project_id
+;
+
+DROP TABLE IF EXISTS projects;
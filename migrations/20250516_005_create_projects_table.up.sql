@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS projects (
+	id		serial 				primary key
+
+	,name		varchar(64) 			not null
+	,archived	boolean				not null default false
+
+	,user_id	integer				not null
+
+	,created_at	timestamp with time zone	not null default current_timestamp
+	,updated_at	timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_projects_user_id_name
+		UNIQUE (user_id, name)
+	,CONSTRAINT fk_projects_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS project_id	integer
+	,ADD CONSTRAINT fk_todos_project
+		FOREIGN KEY (project_id)
+		REFERENCES projects(id)
+		ON DELETE SET NULL
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_project_id ON todos(project_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_todos_parent_id;
+
+ALTER TABLE todos
+	DROP CONSTRAINT IF EXISTS fk_todos_parent
+	,DROP COLUMN IF EXISTS parent_id
+;
@@ -0,0 +1,9 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS parent_id	integer
+	,ADD CONSTRAINT fk_todos_parent
+		FOREIGN KEY (parent_id)
+		REFERENCES todos(id)
+		ON DELETE CASCADE
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos(parent_id);
@@ -0,0 +1,4 @@
+ALTER TABLE todos
+	DROP COLUMN IF EXISTS recurrence_start
+	,DROP COLUMN IF EXISTS recurrence
+;
@@ -0,0 +1,4 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS recurrence		varchar(255)			not null default ''
+	,ADD COLUMN IF NOT EXISTS recurrence_start	timestamp with time zone
+;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_todos_delete_flag_deleted_at;
+
+ALTER TABLE todos
+	DROP COLUMN IF EXISTS deleted_at
+;
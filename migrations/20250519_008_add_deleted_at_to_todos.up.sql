@@ -0,0 +1,5 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS deleted_at	timestamp with time zone
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_delete_flag_deleted_at ON todos(delete_flag, deleted_at);
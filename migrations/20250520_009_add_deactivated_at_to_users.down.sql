@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_delete_flag_deactivated_at;
+
+ALTER TABLE users
+	DROP COLUMN IF EXISTS deactivated_at
+;
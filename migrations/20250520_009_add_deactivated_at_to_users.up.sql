@@ -0,0 +1,5 @@
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS deactivated_at	timestamp with time zone
+;
+
+CREATE INDEX IF NOT EXISTS idx_users_delete_flag_deactivated_at ON users(delete_flag, deactivated_at);
@@ -0,0 +1,9 @@
+ALTER TABLE todos
+	ADD COLUMN IF NOT EXISTS search_vector	tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(title, '')), 'A')
+			|| setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+		) STORED
+;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search_vector ON todos USING GIN (search_vector);
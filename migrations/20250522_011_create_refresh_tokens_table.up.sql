@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id		serial 				primary key
+
+	,user_id	integer				not null
+	,family_id	varchar(64)			not null
+	,token_hash	varchar(64)			not null
+
+	,expires_at	timestamp with time zone	not null
+	,used_at	timestamp with time zone
+	,revoked_at	timestamp with time zone
+
+	,created_at	timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_refresh_tokens_token_hash
+		UNIQUE (token_hash)
+	,CONSTRAINT fk_refresh_tokens_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	jti		varchar(64)			primary key
+
+	,user_id	integer				not null
+
+	,expires_at	timestamp with time zone	not null
+	,revoked_at	timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT fk_revoked_tokens_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_revoked_at ON revoked_tokens(revoked_at);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS tokens_revoked_at	timestamp with time zone
+;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id		serial 				primary key
+
+	,user_id	integer				not null
+	,family_id	varchar(64)			not null
+
+	,user_agent	varchar(255)			not null default ''
+	,ip_address	varchar(45)			not null default ''
+
+	,created_at	timestamp with time zone	not null default current_timestamp
+	,last_seen_at	timestamp with time zone	not null default current_timestamp
+	,revoked_at	timestamp with time zone
+
+	,CONSTRAINT uq_sessions_family_id
+		UNIQUE (family_id)
+	,CONSTRAINT fk_sessions_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_last_seen_at ON sessions(last_seen_at);
@@ -0,0 +1,5 @@
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS role	varchar(16)	not null default 'user'
+	,ADD CONSTRAINT chk_users_role
+		CHECK (role IN ('user', 'admin'))
+;
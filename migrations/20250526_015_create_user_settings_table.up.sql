@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS user_settings (
+	user_id			integer				primary key
+
+	,timezone		varchar(64)			not null default 'UTC'
+	,locale			varchar(8)			not null default ''
+	,default_sort		varchar(32)			not null default ''
+	,week_start		smallint			not null default 1
+	,default_project_id	integer
+
+	,created_at		timestamp with time zone	not null default current_timestamp
+	,updated_at		timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT chk_user_settings_week_start
+		CHECK (week_start BETWEEN 0 AND 6)
+	,CONSTRAINT fk_user_settings_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+	,CONSTRAINT fk_user_settings_default_project
+		FOREIGN KEY (default_project_id)
+		REFERENCES projects(id)
+		ON DELETE SET NULL
+);
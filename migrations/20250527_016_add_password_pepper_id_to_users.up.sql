@@ -0,0 +1,3 @@
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS password_pepper_id	varchar(32)	not null default ''
+;
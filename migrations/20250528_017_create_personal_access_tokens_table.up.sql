@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+	id		serial 				primary key
+
+	,user_id	integer				not null
+	,name		varchar(100)			not null
+	,token_hash	varchar(64)			not null
+	,token_prefix	varchar(16)			not null
+	,scopes		varchar(255)			not null
+
+	,expires_at	timestamp with time zone
+	,last_used_at	timestamp with time zone
+
+	,created_at	timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_personal_access_tokens_token_hash
+		UNIQUE (token_hash)
+	,CONSTRAINT fk_personal_access_tokens_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens(user_id);
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS oauth_clients (
+	id			serial 				primary key
+
+	,client_id		varchar(64)			not null
+	,client_secret_hash	varchar(64)			not null default ''
+	,name			varchar(100)			not null
+	,redirect_uris		text				not null
+	,scopes			varchar(255)			not null
+
+	,created_at		timestamp with time zone	not null default current_timestamp
+	,updated_at		timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_oauth_clients_client_id
+		UNIQUE (client_id)
+);
+
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+	id			serial 				primary key
+
+	,code_hash		varchar(64)			not null
+	,oauth_client_id	integer				not null
+	,user_id		integer				not null
+	,redirect_uri		text				not null
+	,scopes			varchar(255)			not null
+	,code_challenge		varchar(128)			not null
+	,family_id		varchar(64)			not null default ''
+
+	,expires_at		timestamp with time zone	not null
+	,used_at		timestamp with time zone
+
+	,created_at		timestamp with time zone	not null default current_timestamp
+
+	,CONSTRAINT uq_oauth_authorization_codes_code_hash
+		UNIQUE (code_hash)
+	,CONSTRAINT fk_oauth_authorization_codes_client
+		FOREIGN KEY (oauth_client_id)
+		REFERENCES oauth_clients(id)
+		ON DELETE CASCADE
+	,CONSTRAINT fk_oauth_authorization_codes_user
+		FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes(expires_at);
+
+ALTER TABLE sessions
+	ADD COLUMN IF NOT EXISTS oauth_client_id	integer
+	,ADD COLUMN IF NOT EXISTS scopes	varchar(255)	not null default ''
+	,ADD CONSTRAINT fk_sessions_oauth_client
+		FOREIGN KEY (oauth_client_id)
+		REFERENCES oauth_clients(id)
+		ON DELETE CASCADE
+;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_todos_description_trgm;
+DROP INDEX IF EXISTS idx_todos_title_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;